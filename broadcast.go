@@ -0,0 +1,39 @@
+package irsdk
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrBroadcastUnsupported = errors.New("broadcast messages are only supported on Windows")
+	ErrNoSession            = errors.New("no session data")
+	ErrTimeout              = errors.New("the sim did not reach the requested state in time")
+)
+
+// Broadcaster sends broadcast messages to the sim. var1 is a 16 bit value,
+// var2 a 32 bit value: use makeLong to pass two 16 bit values.
+type Broadcaster interface {
+	Broadcast(msg BroadcastMsg, var1 int, var2 int) error
+}
+
+// Pack two 16 bit values in a 32 bit value, like the MAKELONG macro.
+func makeLong(low int, high int) int {
+	return int(int32(uint32(low)&0xFFFF | (uint32(high)&0xFFFF)<<16))
+}
+
+// Update the telemetry until cond returns true or the timeout expires. It is
+// used to confirm that the sim executed a broadcast command.
+func waitTelemetry(sdk *IRSDK, cond func(sdk *IRSDK) bool, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		sdk.Update(false)
+		if cond(sdk) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return ErrTimeout
+		}
+		time.Sleep(16 * time.Millisecond)
+	}
+}
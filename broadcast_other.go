@@ -0,0 +1,8 @@
+//go:build !windows
+
+package irsdk
+
+// Broadcast messages need the Windows message queue.
+func NewBroadcaster() (Broadcaster, error) {
+	return nil, ErrBroadcastUnsupported
+}
@@ -0,0 +1,52 @@
+package irsdk
+
+import "testing"
+
+type sentMsg struct {
+	msg  BroadcastMsg
+	var1 int
+	var2 int
+}
+
+// fakeBroadcaster records the messages instead of sending them to the sim.
+type fakeBroadcaster struct {
+	sent []sentMsg
+}
+
+func (f *fakeBroadcaster) Broadcast(msg BroadcastMsg, var1 int, var2 int) error {
+	f.sent = append(f.sent, sentMsg{msg, var1, var2})
+	return nil
+}
+
+// Return the only message sent since the last call.
+func (f *fakeBroadcaster) last(t *testing.T) sentMsg {
+	t.Helper()
+	if len(f.sent) != 1 {
+		t.Fatalf("expected 1 message, got %d: %v", len(f.sent), f.sent)
+	}
+	m := f.sent[0]
+	f.sent = nil
+	return m
+}
+
+func TestMakeLong(t *testing.T) {
+	tests := []struct {
+		low  int
+		high int
+		want int
+	}{
+		{0, 0, 0},
+		{1, 2, 0x20001},
+		{0xFFFF, 0, 0xFFFF},
+		{0, 1, 0x10000},
+		{0xFFFF, 0xFFFF, -1},
+		{-1, -1, -1},
+		{0, 0x8000, -0x80000000},
+	}
+
+	for _, tt := range tests {
+		if got := makeLong(tt.low, tt.high); got != tt.want {
+			t.Errorf("makeLong(%#x, %#x) = %#x, want %#x", tt.low, tt.high, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package irsdk
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const hwndBroadcast = 0xFFFF
+
+var (
+	user32                = windows.NewLazySystemDLL("user32.dll")
+	procRegisterWindowMsg = user32.NewProc("RegisterWindowMessageW")
+	procSendNotifyMessage = user32.NewProc("SendNotifyMessageW")
+)
+
+type windowsBroadcaster struct {
+	msgID uintptr
+}
+
+// Return a Broadcaster sending window messages to the sim.
+func NewBroadcaster() (Broadcaster, error) {
+	name, err := windows.UTF16PtrFromString(BroadcastMsgName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := procRegisterWindowMsg.Find(); err != nil {
+		return nil, err
+	}
+	id, _, err := procRegisterWindowMsg.Call(uintptr(unsafe.Pointer(name)))
+	if id == 0 {
+		return nil, err
+	}
+
+	return &windowsBroadcaster{msgID: id}, nil
+}
+
+func (b *windowsBroadcaster) Broadcast(msg BroadcastMsg, var1 int, var2 int) error {
+	wParam := uintptr(uint32(makeLong(int(msg), var1)))
+	lParam := uintptr(uint32(var2))
+
+	ok, _, err := procSendNotifyMessage.Call(hwndBroadcast, b.msgID, wParam, lParam)
+	if ok == 0 {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package irsdk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CameraController switches the sim cameras, resolving car numbers, camera
+// groups and cameras by name through the current session data.
+type CameraController struct {
+	sdk         *IRSDK
+	broadcaster Broadcaster
+}
+
+func NewCameraController(sdk *IRSDK, b Broadcaster) *CameraController {
+	return &CameraController{sdk: sdk, broadcaster: b}
+}
+
+// Focus the camera on a car by its number, e.g. "07". Empty group or camera
+// names keep the current selection.
+func (c *CameraController) FocusCar(carNumber string, groupName string, cameraName string) error {
+	num, err := c.carNumber(carNumber)
+	if err != nil {
+		return err
+	}
+	group, camera, err := c.resolveCamera(groupName, cameraName)
+	if err != nil {
+		return err
+	}
+	return c.broadcaster.Broadcast(BroadcastCamSwitchNum, num, makeLong(group, camera))
+}
+
+// Focus the camera on the car in the given race position, starting from 1.
+func (c *CameraController) FocusPosition(position int, groupName string, cameraName string) error {
+	if position <= 0 {
+		return fmt.Errorf("invalid position %d", position)
+	}
+	return c.focusPosition(position, groupName, cameraName)
+}
+
+// Focus the camera on the race leader.
+func (c *CameraController) FocusLeader(groupName string, cameraName string) error {
+	return c.focusPosition(int(csFocusAtLeader), groupName, cameraName)
+}
+
+// Focus the camera on the last incident.
+func (c *CameraController) FocusIncident(groupName string, cameraName string) error {
+	return c.focusPosition(int(csFocusAtIncident), groupName, cameraName)
+}
+
+// Focus the camera on the cars exiting the pits.
+func (c *CameraController) FocusExiting(groupName string, cameraName string) error {
+	return c.focusPosition(int(csFocusAtExiting), groupName, cameraName)
+}
+
+// Set the camera tool state.
+func (c *CameraController) SetState(state CameraState) error {
+	return c.broadcaster.Broadcast(BroadcastCamSetState, int(state), 0)
+}
+
+func (c *CameraController) focusPosition(position int, groupName string, cameraName string) error {
+	group, camera, err := c.resolveCamera(groupName, cameraName)
+	if err != nil {
+		return err
+	}
+	return c.broadcaster.Broadcast(BroadcastCamSwitchPos, position, makeLong(group, camera))
+}
+
+// Return the number to send for a car number. Leading zeros are encoded in
+// the thousands, as expected by the sim.
+func (c *CameraController) carNumber(carNumber string) (int, error) {
+	if c.sdk.Session == nil {
+		return 0, ErrNoSession
+	}
+
+	found := false
+	for _, d := range c.sdk.Session.DriverInfo.Drivers {
+		if d.CarNumber == carNumber && d.IsSpectator == 0 {
+			found = true
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("car number %q not found", carNumber)
+	}
+
+	return padCarNum(carNumber)
+}
+
+func padCarNum(carNumber string) (int, error) {
+	num, err := strconv.Atoi(carNumber)
+	if err != nil || num < 0 {
+		return 0, fmt.Errorf("invalid car number %q", carNumber)
+	}
+
+	trimmed := strings.TrimLeft(carNumber, "0")
+	if trimmed == "" {
+		trimmed = "0"
+	}
+	zeros := len(carNumber) - len(trimmed)
+	if zeros == 0 {
+		return num, nil
+	}
+	return num + 1000*(len(trimmed)+zeros), nil
+}
+
+// Return the group and camera numbers for the given names. Empty names return 0.
+func (c *CameraController) resolveCamera(groupName string, cameraName string) (int, int, error) {
+	if groupName == "" {
+		if cameraName != "" {
+			return 0, 0, fmt.Errorf("camera %q needs a group", cameraName)
+		}
+		return 0, 0, nil
+	}
+	if c.sdk.Session == nil {
+		return 0, 0, ErrNoSession
+	}
+
+	for _, g := range c.sdk.Session.CameraInfo.Groups {
+		if !strings.EqualFold(g.GroupName, groupName) {
+			continue
+		}
+		if cameraName == "" {
+			return g.GroupNum, 0, nil
+		}
+		for _, cam := range g.Cameras {
+			if strings.EqualFold(cam.CameraName, cameraName) {
+				return g.GroupNum, cam.CameraNum, nil
+			}
+		}
+		return 0, 0, fmt.Errorf("camera %q not found in group %q", cameraName, groupName)
+	}
+
+	return 0, 0, fmt.Errorf("camera group %q not found", groupName)
+}
@@ -0,0 +1,114 @@
+package irsdk
+
+// Car joins the CarIdx* telemetry arrays with the session driver for the same car.
+type Car struct {
+	CarIdx int
+	Driver Driver
+
+	Position      int
+	ClassPosition int
+	Lap           int
+	LapCompleted  int
+	LapDistPct    float32
+	TrackSurface  TrackLocation
+	OnPitRoad     bool
+	LastLapTime   float32
+	BestLapTime   float32
+	F2Time        float32
+	EstTime       float32
+	Gear          int
+	RPM           float32
+	Flags         Flags
+}
+
+// Return one Car for every driver in the session, ordered by CarIdx.
+// Spectators and slots without a driver are skipped.
+func (sdk *IRSDK) Cars() []Car {
+	if sdk.Session == nil {
+		return nil
+	}
+
+	position := sdk.varInts("CarIdxPosition")
+	classPosition := sdk.varInts("CarIdxClassPosition")
+	lap := sdk.varInts("CarIdxLap")
+	lapCompleted := sdk.varInts("CarIdxLapCompleted")
+	lapDistPct := sdk.varFloats("CarIdxLapDistPct")
+	trackSurface := sdk.varInts("CarIdxTrackSurface")
+	onPitRoad := sdk.varBools("CarIdxOnPitRoad")
+	lastLapTime := sdk.varFloats("CarIdxLastLapTime")
+	bestLapTime := sdk.varFloats("CarIdxBestLapTime")
+	f2Time := sdk.varFloats("CarIdxF2Time")
+	estTime := sdk.varFloats("CarIdxEstTime")
+	gear := sdk.varInts("CarIdxGear")
+	rpm := sdk.varFloats("CarIdxRPM")
+	flags := sdk.varBitFields("CarIdxSessionFlags")
+
+	drivers := sdk.driversByCarIdx()
+	cars := make([]Car, 0, len(drivers))
+
+	for idx := 0; idx < carIdxCount(sdk); idx++ {
+		d, ok := drivers[idx]
+		if !ok || d.IsSpectator != 0 {
+			continue
+		}
+
+		cars = append(cars, Car{
+			CarIdx:        idx,
+			Driver:        d,
+			Position:      at(position, idx),
+			ClassPosition: at(classPosition, idx),
+			Lap:           at(lap, idx),
+			LapCompleted:  at(lapCompleted, idx),
+			LapDistPct:    at(lapDistPct, idx),
+			TrackSurface:  TrackLocation(at(trackSurface, idx)),
+			OnPitRoad:     at(onPitRoad, idx),
+			LastLapTime:   at(lastLapTime, idx),
+			BestLapTime:   at(bestLapTime, idx),
+			F2Time:        at(f2Time, idx),
+			EstTime:       at(estTime, idx),
+			Gear:          at(gear, idx),
+			RPM:           at(rpm, idx),
+			Flags:         Flags(at(flags, idx)),
+		})
+	}
+
+	return cars
+}
+
+// Return the session drivers keyed by CarIdx.
+func (sdk *IRSDK) driversByCarIdx() map[int]Driver {
+	drivers := make(map[int]Driver)
+	if sdk.Session == nil {
+		return drivers
+	}
+
+	for _, d := range sdk.Session.DriverInfo.Drivers {
+		drivers[d.CarIdx] = d
+	}
+	return drivers
+}
+
+// Return the number of slots in the CarIdx* arrays, falling back to the
+// highest CarIdx in the session when the telemetry is not available.
+func carIdxCount(sdk *IRSDK) int {
+	if v, ok := sdk.Telemetry["CarIdxLapDistPct"]; ok {
+		return v.Header.Count
+	}
+
+	n := 0
+	if sdk.Session != nil {
+		for _, d := range sdk.Session.DriverInfo.Drivers {
+			n = max(n, d.CarIdx+1)
+		}
+	}
+	return n
+}
+
+// Return the element at index i, or the zero value if the slice is too short.
+func at[T any](arr []T, i int) T {
+	var zero T
+	if i < 0 || i >= len(arr) {
+		return zero
+	}
+	return arr[i]
+}
@@ -0,0 +1,172 @@
+package irsdk
+
+type CautionPhase int
+
+const (
+	PhaseGreen      CautionPhase = iota
+	PhaseCautionOut              // caution waving, field catching the pace car
+	PhasePitsClosed
+	PhasePitsOpen
+	PhaseOneToGreen // until the pace car releases the field
+	PhaseRestart    // field released, until the leader completes a lap
+)
+
+// CautionEvent is emitted on every phase change.
+type CautionEvent struct {
+	From        CautionPhase
+	To          CautionPhase
+	SessionTime float64
+	LeaderLap   int
+	PaceMode    PaceMode
+}
+
+// CarPaceStatus is the place of a car in the pace line during a caution.
+type CarPaceStatus struct {
+	CarIdx      int
+	Line        int
+	Row         int
+	EndOfLine   bool
+	FreePass    bool // lucky dog
+	WavedAround bool
+}
+
+// CautionTracker interprets the caution flags, PaceMode and the CarIdxPace*
+// arrays. The caution bits give the phases up to one to green, PaceMode tells
+// when the field is released and whether the restart is single or double
+// file. Call Update on every tick.
+type CautionTracker struct {
+	Phase    CautionPhase
+	PaceMode PaceMode
+	// Single or double file restart of the current or last caution,
+	// PaceModeNotPacing until the pace car leads a restart.
+	RestartMode PaceMode
+	PaceCarIdx  int
+	Cautions    int
+	CautionLaps int
+	Cars        map[int]CarPaceStatus
+
+	sessionNum int
+	leaderLap  int
+	restartLap int
+}
+
+func NewCautionTracker() *CautionTracker {
+	return &CautionTracker{
+		Cars:        make(map[int]CarPaceStatus),
+		RestartMode: PaceModeNotPacing,
+		PaceCarIdx:  -1,
+		sessionNum:  -1,
+	}
+}
+
+// Consume the current telemetry and return the phase changes since the previous call.
+func (c *CautionTracker) Update(sdk *IRSDK) []CautionEvent {
+	sessionNum := sdk.varInt("SessionNum")
+	if sessionNum != c.sessionNum {
+		c.sessionNum = sessionNum
+		c.Phase = PhaseGreen
+		c.Cautions = 0
+		c.CautionLaps = 0
+		c.RestartMode = PaceModeNotPacing
+		c.leaderLap = 0
+	}
+
+	if sdk.Session != nil {
+		c.PaceCarIdx = sdk.Session.DriverInfo.PaceCarIdx
+	}
+	c.PaceMode = PaceMode(sdk.varInt("PaceMode"))
+
+	leaderLap := 0
+	for idx, lap := range sdk.varInts("CarIdxLapCompleted") {
+		if idx != c.PaceCarIdx {
+			leaderLap = max(leaderLap, lap)
+		}
+	}
+	if leaderLap > c.leaderLap && c.underCaution() {
+		c.CautionLaps += leaderLap - c.leaderLap
+	}
+	c.leaderLap = max(c.leaderLap, leaderLap)
+
+	phase := c.nextPhase(Flags(sdk.varBitField("SessionFlags")), sdk.varBool("PitsOpen"), c.PaceMode)
+
+	var events []CautionEvent
+	if phase != c.Phase {
+		if !c.underCaution() && isCautionPhase(phase) {
+			c.Cautions++
+			c.RestartMode = PaceModeNotPacing
+		}
+		if phase == PhaseRestart {
+			c.restartLap = c.leaderLap
+		}
+		events = append(events, CautionEvent{c.Phase, phase, sdk.varDouble("SessionTime"), c.leaderLap, c.PaceMode})
+		c.Phase = phase
+	}
+	if c.underCaution() && isRestartMode(c.PaceMode) {
+		c.RestartMode = c.PaceMode
+	}
+
+	c.Cars = make(map[int]CarPaceStatus)
+	if c.underCaution() {
+		lines := sdk.varInts("CarIdxPaceLine")
+		rows := sdk.varInts("CarIdxPaceRow")
+		flags := sdk.varBitFields("CarIdxPaceFlags")
+		for idx := range lines {
+			if idx == c.PaceCarIdx || lines[idx] < 0 {
+				continue
+			}
+			f := PaceFlags(at(flags, idx))
+			c.Cars[idx] = CarPaceStatus{
+				CarIdx:      idx,
+				Line:        lines[idx],
+				Row:         at(rows, idx),
+				EndOfLine:   f&PaceFlagsEndOfLine != 0,
+				FreePass:    f&PaceFlagsFreePass != 0,
+				WavedAround: f&PaceFlagsWavedAround != 0,
+			}
+		}
+	}
+
+	return events
+}
+
+func (c *CautionTracker) underCaution() bool {
+	return isCautionPhase(c.Phase)
+}
+
+func isCautionPhase(p CautionPhase) bool {
+	return p != PhaseGreen && p != PhaseRestart
+}
+
+func isRestartMode(m PaceMode) bool {
+	return m == PaceModeSingleFileRestart || m == PaceModeDoubleFileRestart
+}
+
+func (c *CautionTracker) nextPhase(flags Flags, pitsOpen bool, mode PaceMode) CautionPhase {
+	if flags&(FlagCaution|FlagCautionWaving) != 0 {
+		released := mode == PaceModeNotPacing
+		switch {
+		case c.Phase == PhaseOneToGreen && released:
+			// The pace car released the field before the caution bits cleared.
+			return PhaseRestart
+		case c.Phase == PhaseRestart && released && flags&FlagCautionWaving == 0:
+			// Caution bits left over from the restart, a new caution starts waving.
+			return PhaseRestart
+		case flags&FlagOneLapToGreen != 0:
+			return PhaseOneToGreen
+		case flags&FlagCautionWaving != 0 && c.Phase != PhasePitsOpen && c.Phase != PhasePitsClosed:
+			return PhaseCautionOut
+		case pitsOpen:
+			return PhasePitsOpen
+		default:
+			return PhasePitsClosed
+		}
+	}
+
+	if c.underCaution() {
+		return PhaseRestart
+	}
+	if c.Phase == PhaseRestart && c.leaderLap <= c.restartLap {
+		return PhaseRestart
+	}
+	return PhaseGreen
+}
@@ -0,0 +1,73 @@
+package irsdk
+
+import "testing"
+
+type cautionStep struct {
+	flags    Flags
+	pitsOpen bool
+	mode     PaceMode
+	lap      int
+	want     CautionPhase
+}
+
+func runCautionSteps(t *testing.T, c *CautionTracker, steps []cautionStep) {
+	t.Helper()
+	sdk := &IRSDK{}
+	for i, step := range steps {
+		sdk.Telemetry = map[string]TelemetryVar{
+			"SessionNum":         intVar(0),
+			"SessionTime":        doubleVar(float64(i)),
+			"SessionFlags":       bitFieldVar(uint32(step.flags)),
+			"PitsOpen":           boolVar(step.pitsOpen),
+			"PaceMode":           intVar(int(step.mode)),
+			"CarIdxLapCompleted": intVar(step.lap, step.lap-1),
+		}
+		c.Update(sdk)
+		if c.Phase != step.want {
+			t.Fatalf("step %d: phase %d, want %d", i, c.Phase, step.want)
+		}
+	}
+}
+
+func TestCautionTrackerPhases(t *testing.T) {
+	c := NewCautionTracker()
+	runCautionSteps(t, c, []cautionStep{
+		{FlagGreen, true, PaceModeNotPacing, 10, PhaseGreen},
+		{FlagCautionWaving, false, PaceModeNotPacing, 10, PhaseCautionOut},
+		{FlagCaution, false, PaceModeDoubleFileRestart, 11, PhasePitsClosed},
+		{FlagCaution, true, PaceModeDoubleFileRestart, 12, PhasePitsOpen},
+		{FlagCaution | FlagOneLapToGreen, true, PaceModeDoubleFileRestart, 13, PhaseOneToGreen},
+		// The pace car releases the field before the caution bits clear.
+		{FlagCaution, true, PaceModeNotPacing, 13, PhaseRestart},
+		{FlagGreen, true, PaceModeNotPacing, 13, PhaseRestart},
+		{FlagGreen, true, PaceModeNotPacing, 14, PhaseGreen},
+	})
+
+	if c.Cautions != 1 || c.CautionLaps != 3 {
+		t.Errorf("cautions %d, caution laps %d, want 1 and 3", c.Cautions, c.CautionLaps)
+	}
+	if c.RestartMode != PaceModeDoubleFileRestart {
+		t.Errorf("restart mode %d, want double file", c.RestartMode)
+	}
+}
+
+func TestCautionTrackerRestartMode(t *testing.T) {
+	c := NewCautionTracker()
+	runCautionSteps(t, c, []cautionStep{
+		{FlagCautionWaving, false, PaceModeNotPacing, 10, PhaseCautionOut},
+		{FlagCaution | FlagOneLapToGreen, true, PaceModeSingleFileRestart, 11, PhaseOneToGreen},
+		{FlagGreen, true, PaceModeNotPacing, 11, PhaseRestart},
+		{FlagGreen, true, PaceModeNotPacing, 12, PhaseGreen},
+	})
+	if c.RestartMode != PaceModeSingleFileRestart {
+		t.Errorf("restart mode %d, want single file", c.RestartMode)
+	}
+
+	// A new caution forgets the previous restart mode until the field is paced.
+	runCautionSteps(t, c, []cautionStep{
+		{FlagCautionWaving, false, PaceModeNotPacing, 20, PhaseCautionOut},
+	})
+	if c.RestartMode != PaceModeNotPacing || c.Cautions != 2 {
+		t.Errorf("restart mode %d, cautions %d", c.RestartMode, c.Cautions)
+	}
+}
@@ -0,0 +1,151 @@
+package irsdk
+
+import (
+	"math"
+	"sort"
+)
+
+// CarClass describes a class of cars in the session.
+type CarClass struct {
+	ID         int
+	ShortName  string
+	Color      int
+	RelSpeed   int
+	EstLapTime float64
+	CarIdxs    []int
+}
+
+// ClassRegistry holds the car classes of a session, fastest first.
+type ClassRegistry struct {
+	Classes []*CarClass
+
+	byID     map[int]*CarClass
+	byCarIdx map[int]*CarClass
+}
+
+// Build the class registry from the drivers in the session.
+func NewClassRegistry(s *Session) *ClassRegistry {
+	r := &ClassRegistry{
+		byID:     make(map[int]*CarClass),
+		byCarIdx: make(map[int]*CarClass),
+	}
+	if s == nil {
+		return r
+	}
+
+	for _, d := range s.DriverInfo.Drivers {
+		if d.IsSpectator != 0 || d.CarIsPaceCar != 0 {
+			continue
+		}
+
+		c, ok := r.byID[d.CarClassID]
+		if !ok {
+			c = &CarClass{
+				ID:         d.CarClassID,
+				ShortName:  d.CarClassShortName,
+				Color:      d.CarClassColor,
+				RelSpeed:   d.CarClassRelSpeed,
+				EstLapTime: d.CarClassEstLapTime,
+			}
+			r.byID[c.ID] = c
+			r.Classes = append(r.Classes, c)
+		}
+		c.CarIdxs = append(c.CarIdxs, d.CarIdx)
+		r.byCarIdx[d.CarIdx] = c
+	}
+
+	sort.SliceStable(r.Classes, func(i, j int) bool { return r.Classes[i].RelSpeed > r.Classes[j].RelSpeed })
+	return r
+}
+
+// Return true if more than one class is racing.
+func (r *ClassRegistry) IsMultiClass() bool {
+	return len(r.Classes) > 1
+}
+
+func (r *ClassRegistry) Class(id int) (*CarClass, bool) {
+	c, ok := r.byID[id]
+	return c, ok
+}
+
+// Return the class of a car.
+func (r *ClassRegistry) ClassOf(carIdx int) (*CarClass, bool) {
+	c, ok := r.byCarIdx[carIdx]
+	return c, ok
+}
+
+// Return the CarIdx of the leader of every class, by class ID.
+func (r *ClassRegistry) Leaders(sdk *IRSDK) map[int]int {
+	positions := sdk.varInts("CarIdxClassPosition")
+	leaders := make(map[int]int)
+
+	for _, c := range r.Classes {
+		best := 0
+		for _, idx := range c.CarIdxs {
+			pos := at(positions, idx)
+			if pos > 0 && (best == 0 || pos < best) {
+				best = pos
+				leaders[c.ID] = idx
+			}
+		}
+	}
+	return leaders
+}
+
+// Return the gap of a car to the leader of its class, see GapCalculator.Gap.
+func (r *ClassRegistry) GapToClassLeader(sdk *IRSDK, g *GapCalculator, carIdx int) (seconds float64, laps int, ok bool) {
+	c, found := r.ClassOf(carIdx)
+	if !found {
+		return 0, 0, false
+	}
+	leader, found := r.Leaders(sdk)[c.ID]
+	if !found {
+		return 0, 0, false
+	}
+	return g.Gap(leader, carIdx)
+}
+
+// ApproachingCar is a car of a faster class closing in on the focus car.
+type ApproachingCar struct {
+	CarIdx int
+	Class  *CarClass
+	// Seconds behind the focus car and estimated seconds until it catches up.
+	Gap         float64
+	TimeToCatch float64
+}
+
+// Return the cars of faster classes, according to CarClassRelSpeed, that are
+// expected to reach the focus car within horizon seconds, nearest first.
+func (r *ClassRegistry) FasterClassApproaching(sdk *IRSDK, focusIdx int, horizon float64) []ApproachingCar {
+	focus, ok := r.ClassOf(focusIdx)
+	if !ok {
+		return nil
+	}
+
+	var approaching []ApproachingCar
+	for _, car := range sdk.Relative(focusIdx, len(r.byCarIdx)).Behind {
+		c, ok := r.ClassOf(car.CarIdx)
+		if !ok || c.RelSpeed <= focus.RelSpeed || car.OnPitRoad {
+			continue
+		}
+
+		gap := math.Abs(car.Delta)
+		// Time gained by the faster car every second, from the lap time estimates.
+		// Without estimates fall back to the relative speed of the classes.
+		closing := float64(c.RelSpeed-focus.RelSpeed) / float64(c.RelSpeed)
+		if c.EstLapTime > 0 && focus.EstLapTime > 0 {
+			closing = (focus.EstLapTime - c.EstLapTime) / focus.EstLapTime
+		}
+		if closing <= 0 {
+			continue
+		}
+
+		t := gap / closing
+		if t <= horizon {
+			approaching = append(approaching, ApproachingCar{car.CarIdx, c, gap, t})
+		}
+	}
+
+	sort.Slice(approaching, func(i, j int) bool { return approaching[i].TimeToCatch < approaching[j].TimeToCatch })
+	return approaching
+}
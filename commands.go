@@ -0,0 +1,109 @@
+package irsdk
+
+import (
+	"fmt"
+	"time"
+)
+
+// ChatController opens, replies to and closes the chat, and sends chat macros.
+type ChatController struct {
+	broadcaster Broadcaster
+}
+
+func NewChatController(b Broadcaster) *ChatController {
+	return &ChatController{broadcaster: b}
+}
+
+// Send one of the chat macros configured in the sim, numbered from 1 to 15.
+func (c *ChatController) Macro(num int) error {
+	if num < 1 || num > 15 {
+		return fmt.Errorf("invalid chat macro %d, must be between 1 and 15", num)
+	}
+	// The sim numbers the macros from 0.
+	return c.broadcaster.Broadcast(BroadcastChatComand, int(ChatCommand_Macro), num-1)
+}
+
+func (c *ChatController) Begin() error {
+	return c.broadcaster.Broadcast(BroadcastChatComand, int(ChatCommand_BeginChat), 0)
+}
+
+func (c *ChatController) Reply() error {
+	return c.broadcaster.Broadcast(BroadcastChatComand, int(ChatCommand_Reply), 0)
+}
+
+func (c *ChatController) Cancel() error {
+	return c.broadcaster.Broadcast(BroadcastChatComand, int(ChatCommand_Cancel), 0)
+}
+
+// TelemetryController turns the recording of IBT files on and off. With a
+// timeout greater than zero Start and Stop wait for IsDiskLoggingActive to
+// confirm the new state.
+type TelemetryController struct {
+	sdk         *IRSDK
+	broadcaster Broadcaster
+}
+
+func NewTelemetryController(sdk *IRSDK, b Broadcaster) *TelemetryController {
+	return &TelemetryController{sdk: sdk, broadcaster: b}
+}
+
+func (t *TelemetryController) send(cmd TelemCommandMode, active bool, timeout time.Duration) error {
+	if err := t.broadcaster.Broadcast(BroadcastTelemCommand, int(cmd), 0); err != nil {
+		return err
+	}
+	if timeout <= 0 {
+		return nil
+	}
+	return waitTelemetry(t.sdk, func(sdk *IRSDK) bool { return sdk.varBool("IsDiskLoggingActive") == active }, timeout)
+}
+
+func (t *TelemetryController) Start(timeout time.Duration) error {
+	return t.send(TelemCommand_Start, true, timeout)
+}
+
+func (t *TelemetryController) Stop(timeout time.Duration) error {
+	return t.send(TelemCommand_Stop, false, timeout)
+}
+
+// Write the current file to disk and start a new one. It can't be confirmed:
+// IsDiskLoggingActive stays true across the restart.
+func (t *TelemetryController) Restart() error {
+	return t.send(TelemCommand_Restart, true, 0)
+}
+
+// VideoController takes screenshots and controls the video capture.
+type VideoController struct {
+	broadcaster Broadcaster
+}
+
+func NewVideoController(b Broadcaster) *VideoController {
+	return &VideoController{broadcaster: b}
+}
+
+func (v *VideoController) send(mode VideoCaptureMode) error {
+	return v.broadcaster.Broadcast(BroadcastVideoCapture, int(mode), 0)
+}
+
+func (v *VideoController) Screenshot() error {
+	return v.send(VideoCapture_TriggerScreenShot)
+}
+
+func (v *VideoController) Start() error {
+	return v.send(VideoCaptuer_StartVideoCapture)
+}
+
+func (v *VideoController) Stop() error {
+	return v.send(VideoCaptuer_EndVideoCapture)
+}
+
+func (v *VideoController) Toggle() error {
+	return v.send(VideoCaptuer_ToggleVideoCapture)
+}
+
+// Show or hide the video timer in the upper left corner of the screen.
+func (v *VideoController) ShowTimer(show bool) error {
+	if show {
+		return v.send(VideoCaptuer_ShowVideoTimer)
+	}
+	return v.send(VideoCaptuer_HideVideoTimer)
+}
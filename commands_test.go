@@ -0,0 +1,102 @@
+package irsdk
+
+import "testing"
+
+func TestChatController(t *testing.T) {
+	b := &fakeBroadcaster{}
+	chat := NewChatController(b)
+
+	tests := []struct {
+		name string
+		send func() error
+		want sentMsg
+	}{
+		{"macro 1", func() error { return chat.Macro(1) }, sentMsg{BroadcastChatComand, int(ChatCommand_Macro), 0}},
+		{"macro 15", func() error { return chat.Macro(15) }, sentMsg{BroadcastChatComand, int(ChatCommand_Macro), 14}},
+		{"begin", chat.Begin, sentMsg{BroadcastChatComand, int(ChatCommand_BeginChat), 0}},
+		{"reply", chat.Reply, sentMsg{BroadcastChatComand, int(ChatCommand_Reply), 0}},
+		{"cancel", chat.Cancel, sentMsg{BroadcastChatComand, int(ChatCommand_Cancel), 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.send(); err != nil {
+				t.Fatal(err)
+			}
+			if got := b.last(t); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatControllerInvalidMacro(t *testing.T) {
+	b := &fakeBroadcaster{}
+	chat := NewChatController(b)
+
+	for _, num := range []int{0, 16} {
+		if err := chat.Macro(num); err == nil {
+			t.Errorf("macro %d: expected an error", num)
+		}
+	}
+	if len(b.sent) != 0 {
+		t.Errorf("invalid macros sent %v", b.sent)
+	}
+}
+
+func TestVideoController(t *testing.T) {
+	b := &fakeBroadcaster{}
+	video := NewVideoController(b)
+
+	tests := []struct {
+		name string
+		send func() error
+		mode VideoCaptureMode
+	}{
+		{"screenshot", video.Screenshot, VideoCapture_TriggerScreenShot},
+		{"start", video.Start, VideoCaptuer_StartVideoCapture},
+		{"stop", video.Stop, VideoCaptuer_EndVideoCapture},
+		{"toggle", video.Toggle, VideoCaptuer_ToggleVideoCapture},
+		{"show timer", func() error { return video.ShowTimer(true) }, VideoCaptuer_ShowVideoTimer},
+		{"hide timer", func() error { return video.ShowTimer(false) }, VideoCaptuer_HideVideoTimer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.send(); err != nil {
+				t.Fatal(err)
+			}
+			want := sentMsg{BroadcastVideoCapture, int(tt.mode), 0}
+			if got := b.last(t); got != want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestTelemetryControllerWithoutConfirmation(t *testing.T) {
+	b := &fakeBroadcaster{}
+	telem := NewTelemetryController(&IRSDK{}, b)
+
+	tests := []struct {
+		name string
+		send func() error
+		cmd  TelemCommandMode
+	}{
+		{"start", func() error { return telem.Start(0) }, TelemCommand_Start},
+		{"stop", func() error { return telem.Stop(0) }, TelemCommand_Stop},
+		{"restart", telem.Restart, TelemCommand_Restart},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.send(); err != nil {
+				t.Fatal(err)
+			}
+			want := sentMsg{BroadcastTelemCommand, int(tt.cmd), 0}
+			if got := b.last(t); got != want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
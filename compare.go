@@ -0,0 +1,101 @@
+package irsdk
+
+// Return the time elapsed since the start of the lap when the car was at the
+// given LapDistPct.
+func (l *RecordedLap) ElapsedAt(pct float64) (float64, bool) {
+	t, ok := l.At("SessionTime", pct)
+	if !ok {
+		return 0, false
+	}
+	return t - l.StartTime, true
+}
+
+// ChannelComparison holds a channel of two laps sampled at the same distances.
+type ChannelComparison struct {
+	A     []float64
+	B     []float64
+	Delta []float64
+}
+
+// LapComparison aligns two laps by distance. TimeDelta is the time lost by
+// lap A against lap B at every point: positive when A is slower.
+type LapComparison struct {
+	Distance  []float64
+	TimeDelta []float64
+	Channels  map[string]ChannelComparison
+}
+
+// Compare two laps channel by channel at the given number of evenly spaced points.
+func CompareLaps(a *RecordedLap, b *RecordedLap, points int) LapComparison {
+	if points <= 0 {
+		points = 1000
+	}
+
+	c := LapComparison{
+		Distance:  make([]float64, points),
+		TimeDelta: make([]float64, points),
+		Channels:  make(map[string]ChannelComparison),
+	}
+
+	for i := range c.Distance {
+		c.Distance[i] = float64(i) / float64(points)
+	}
+
+	for name := range a.Channels {
+		if _, ok := b.Channels[name]; !ok {
+			continue
+		}
+
+		cc := ChannelComparison{
+			A:     make([]float64, points),
+			B:     make([]float64, points),
+			Delta: make([]float64, points),
+		}
+		for i, pct := range c.Distance {
+			cc.A[i], _ = a.At(name, pct)
+			cc.B[i], _ = b.At(name, pct)
+			cc.Delta[i] = cc.A[i] - cc.B[i]
+		}
+		c.Channels[name] = cc
+	}
+
+	for i, pct := range c.Distance {
+		ta, okA := a.ElapsedAt(pct)
+		tb, okB := b.ElapsedAt(pct)
+		if okA && okB {
+			c.TimeDelta[i] = ta - tb
+		}
+	}
+
+	return c
+}
+
+// DeltaTracker computes the live delta of the player's car against a
+// reference lap, like LapDeltaToBestLap but for any recorded lap.
+type DeltaTracker struct {
+	Reference *RecordedLap
+}
+
+func NewDeltaTracker(reference *RecordedLap) *DeltaTracker {
+	return &DeltaTracker{Reference: reference}
+}
+
+// Return the time gained or lost on the current lap against the reference:
+// positive when the current lap is slower.
+func (d *DeltaTracker) Update(sdk *IRSDK) (float64, bool) {
+	if d.Reference == nil || !sdk.varBool("IsOnTrack") {
+		return 0, false
+	}
+
+	pct := float64(sdk.varFloat("LapDistPct"))
+	if pct < 0 {
+		return 0, false
+	}
+
+	ref, ok := d.Reference.ElapsedAt(pct)
+	if !ok {
+		return 0, false
+	}
+
+	return float64(sdk.varFloat("LapCurrentLapTime")) - ref, true
+}
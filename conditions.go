@@ -0,0 +1,222 @@
+package irsdk
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ConditionsSample holds the track and weather conditions at a given time.
+// Temperatures are in °C, wind speed in m/s, wind direction in rad and
+// precipitation, humidity and fog between 0 and 1.
+type ConditionsSample struct {
+	SessionTime   float64
+	TrackTemp     float64
+	AirTemp       float64
+	Precipitation float64
+	WindVel       float64
+	WindDir       float64
+	Humidity      float64
+	FogLevel      float64
+	Wetness       TrackWetness
+	Skies         Skies
+}
+
+type Trend int
+
+const (
+	TrendStable Trend = iota
+	TrendRising
+	TrendFalling
+)
+
+type ConditionsEventType int
+
+const (
+	WetnessChanged ConditionsEventType = iota
+	SkiesChanged
+)
+
+// ConditionsEvent is emitted when the track wetness or the skies change.
+type ConditionsEvent struct {
+	Type        ConditionsEventType
+	SessionTime float64
+
+	// Values of TrackWetness or Skies, depending on Type.
+	From int
+	To   int
+}
+
+// ConditionsTracker samples the weather telemetry over time and reports
+// trends. Call Update on every tick.
+type ConditionsTracker struct {
+	// Seconds between samples and seconds of history kept.
+	Interval float64
+	Window   float64
+	// Change in °C per minute above which a temperature is considered moving.
+	TempThreshold float64
+
+	Samples []ConditionsSample
+
+	current ConditionsSample
+	started bool
+}
+
+func NewConditionsTracker() *ConditionsTracker {
+	return &ConditionsTracker{
+		Interval:      10,
+		Window:        600,
+		TempThreshold: 0.05,
+	}
+}
+
+// Read the current conditions from the telemetry.
+func readConditions(sdk *IRSDK) ConditionsSample {
+	return ConditionsSample{
+		SessionTime:   sdk.varDouble("SessionTime"),
+		TrackTemp:     float64(sdk.varFloat("TrackTempCrew")),
+		AirTemp:       float64(sdk.varFloat("AirTemp")),
+		Precipitation: float64(sdk.varFloat("Precipitation")),
+		WindVel:       float64(sdk.varFloat("WindVel")),
+		WindDir:       float64(sdk.varFloat("WindDir")),
+		Humidity:      float64(sdk.varFloat("RelativeHumidity")),
+		FogLevel:      float64(sdk.varFloat("FogLevel")),
+		Wetness:       TrackWetness(sdk.varInt("TrackWetness")),
+		Skies:         Skies(sdk.varInt("Skies")),
+	}
+}
+
+// Consume the current telemetry and return the changes since the previous call.
+// The first call only records the current state.
+func (c *ConditionsTracker) Update(sdk *IRSDK) []ConditionsEvent {
+	cur := readConditions(sdk)
+	prev := c.current
+	c.current = cur
+
+	// Restart the history when the time goes backwards, e.g. in a new session.
+	if n := len(c.Samples); n > 0 && cur.SessionTime < c.Samples[n-1].SessionTime {
+		c.Samples = nil
+	}
+	if n := len(c.Samples); n == 0 || cur.SessionTime-c.Samples[n-1].SessionTime >= c.Interval {
+		c.Samples = append(c.Samples, cur)
+		for len(c.Samples) > 0 && cur.SessionTime-c.Samples[0].SessionTime > c.Window {
+			c.Samples = c.Samples[1:]
+		}
+	}
+
+	if !c.started {
+		c.started = true
+		return nil
+	}
+
+	var events []ConditionsEvent
+	if cur.Wetness != prev.Wetness {
+		events = append(events, ConditionsEvent{WetnessChanged, cur.SessionTime, int(prev.Wetness), int(cur.Wetness)})
+	}
+	if cur.Skies != prev.Skies {
+		events = append(events, ConditionsEvent{SkiesChanged, cur.SessionTime, int(prev.Skies), int(cur.Skies)})
+	}
+	return events
+}
+
+// Return the latest conditions.
+func (c *ConditionsTracker) Current() ConditionsSample {
+	return c.current
+}
+
+// Return the trend of the track temperature over the history window.
+func (c *ConditionsTracker) TrackTempTrend() Trend {
+	return c.trend(func(s ConditionsSample) float64 { return s.TrackTemp }, c.TempThreshold)
+}
+
+// Return the trend of the air temperature over the history window.
+func (c *ConditionsTracker) AirTempTrend() Trend {
+	return c.trend(func(s ConditionsSample) float64 { return s.AirTemp }, c.TempThreshold)
+}
+
+// Return the trend of the track wetness over the history window: falling
+// means the track is drying.
+func (c *ConditionsTracker) WetnessTrend() Trend {
+	n := len(c.Samples)
+	if n < 2 {
+		return TrendStable
+	}
+	first, last := c.Samples[0].Wetness, c.Samples[n-1].Wetness
+	switch {
+	case last > first:
+		return TrendRising
+	case last < first:
+		return TrendFalling
+	}
+	return TrendStable
+}
+
+// Return the trend of a value from the slope of its linear regression, in
+// units per minute.
+func (c *ConditionsTracker) trend(value func(ConditionsSample) float64, threshold float64) Trend {
+	n := float64(len(c.Samples))
+	if n < 2 {
+		return TrendStable
+	}
+
+	var sx, sy, sxx, sxy float64
+	for _, s := range c.Samples {
+		x := s.SessionTime / 60
+		y := value(s)
+		sx += x
+		sy += y
+		sxx += x * x
+		sxy += x * y
+	}
+	den := n*sxx - sx*sx
+	if den == 0 {
+		return TrendStable
+	}
+
+	slope := (n*sxy - sx*sy) / den
+	switch {
+	case slope > threshold:
+		return TrendRising
+	case slope < -threshold:
+		return TrendFalling
+	}
+	return TrendStable
+}
+
+// Return the conditions announced in the weekend info, converted to the same
+// units as the telemetry.
+func WeekendConditions(s *Session) ConditionsSample {
+	if s == nil {
+		return ConditionsSample{}
+	}
+
+	w := s.WeekendInfo
+	c := ConditionsSample{
+		TrackTemp: parseUnitValue(w.TrackSurfaceTemp),
+		AirTemp:   parseUnitValue(w.TrackAirTemp),
+		WindVel:   parseUnitValue(w.TrackWindVel),
+		WindDir:   parseUnitValue(w.TrackWindDir),
+		Humidity:  parseUnitValue(w.TrackRelativeHumidity) / 100,
+		FogLevel:  parseUnitValue(w.TrackFogLevel) / 100,
+	}
+
+	switch strings.ToLower(w.TrackSkies) {
+	case "partly cloudy":
+		c.Skies = SkiesPartlyCloudy
+	case "mostly cloudy":
+		c.Skies = SkiesMostlyCloudy
+	case "overcast":
+		c.Skies = SkiesOvercast
+	}
+
+	return c
+}
+
+// Return the number in a session value like "25.30 C" or "55 %".
+func parseUnitValue(s string) float64 {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0
+	}
+	v, _ := strconv.ParseFloat(fields[0], 64)
+	return v
+}
@@ -0,0 +1,222 @@
+package irsdk
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+)
+
+const (
+	// Number of points used to scan a lap.
+	cornerScanPoints = 1000
+	// Lateral acceleration in m/s^2 and steering angle in rad above which
+	// the car is considered to be cornering.
+	cornerMinLatAccel = 4.0
+	cornerMinSteering = 0.3
+	// Shortest corner and straight, as a fraction of the lap.
+	cornerMinLength = 0.004
+	cornerMinGap    = 0.01
+	// Pedal positions, between 0 and 1, that count as braking or accelerating.
+	brakeThreshold    = 0.1
+	throttleThreshold = 0.2
+)
+
+// TrackSegment is a corner or a straight, between two LapDistPct.
+type TrackSegment struct {
+	Number int
+	Corner bool
+	Start  float64
+	End    float64
+}
+
+// TrackSegments splits a track configuration into corners and straights.
+// Corners are numbered from 1, straights have Number 0.
+type TrackSegments struct {
+	TrackID         int
+	TrackConfigName string
+	Segments        []TrackSegment
+}
+
+// CornerStats are the metrics of a lap through a single corner. Positions are
+// LapDistPct and are -1 when not found, speeds are in m/s.
+type CornerStats struct {
+	Corner int
+
+	EntrySpeed float64
+	ApexSpeed  float64
+	ExitSpeed  float64
+	MinSpeed   float64
+
+	Apex           float64
+	BrakePoint     float64
+	ThrottlePickup float64
+
+	Time     float64
+	TimeLost float64
+}
+
+// Find the corners of a track from a clean lap using LatAccel and
+// SteeringWheelAngle.
+func DetectCorners(lap *RecordedLap, trackID int, config string) *TrackSegments {
+	cornering := make([]bool, cornerScanPoints)
+	for i := range cornering {
+		pct := float64(i) / cornerScanPoints
+		lat, _ := lap.At("LatAccel", pct)
+		steer, _ := lap.At("SteeringWheelAngle", pct)
+		cornering[i] = math.Abs(lat) > cornerMinLatAccel || math.Abs(steer) > cornerMinSteering
+	}
+
+	// Build the corner ranges, then clean them up.
+	var corners [][2]float64
+	for i := 0; i < cornerScanPoints; i++ {
+		if !cornering[i] {
+			continue
+		}
+		start := i
+		for i < cornerScanPoints && cornering[i] {
+			i++
+		}
+		corners = append(corners, [2]float64{float64(start) / cornerScanPoints, float64(i) / cornerScanPoints})
+	}
+
+	var merged [][2]float64
+	for _, c := range corners {
+		if n := len(merged); n > 0 && c[0]-merged[n-1][1] < cornerMinGap {
+			merged[n-1][1] = c[1]
+			continue
+		}
+		merged = append(merged, c)
+	}
+
+	t := &TrackSegments{TrackID: trackID, TrackConfigName: config}
+	pos := 0.0
+	number := 0
+	for _, c := range merged {
+		if c[1]-c[0] < cornerMinLength {
+			continue
+		}
+		if c[0] > pos {
+			t.Segments = append(t.Segments, TrackSegment{Start: pos, End: c[0]})
+		}
+		number++
+		t.Segments = append(t.Segments, TrackSegment{Number: number, Corner: true, Start: c[0], End: c[1]})
+		pos = c[1]
+	}
+	if pos < 1 {
+		t.Segments = append(t.Segments, TrackSegment{Start: pos, End: 1})
+	}
+
+	return t
+}
+
+// Return the corners, without the straights.
+func (t *TrackSegments) Corners() []TrackSegment {
+	var corners []TrackSegment
+	for _, s := range t.Segments {
+		if s.Corner {
+			corners = append(corners, s)
+		}
+	}
+	return corners
+}
+
+// Write the segments to dir, in a file named after the track and configuration.
+func (t *TrackSegments) Save(dir string) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(trackFile(dir, t.TrackID, t.TrackConfigName, "corners"), data, 0644)
+}
+
+// Read the segments of a track saved in dir by Save.
+func LoadTrackSegments(dir string, trackID int, config string) (*TrackSegments, error) {
+	data, err := os.ReadFile(trackFile(dir, trackID, config, "corners"))
+	if err != nil {
+		return nil, err
+	}
+
+	t := &TrackSegments{}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Compute the metrics of every corner of a lap. The time lost is computed
+// against ref, which can be nil.
+func (t *TrackSegments) CornerStats(lap *RecordedLap, ref *RecordedLap) []CornerStats {
+	var stats []CornerStats
+
+	prevEnd := 0.0
+	for _, seg := range t.Segments {
+		if !seg.Corner {
+			continue
+		}
+
+		s := CornerStats{
+			Corner:         seg.Number,
+			BrakePoint:     -1,
+			ThrottlePickup: -1,
+			MinSpeed:       math.Inf(1),
+		}
+		s.EntrySpeed, _ = lap.At("Speed", seg.Start)
+		s.ExitSpeed, _ = lap.At("Speed", seg.End)
+
+		// Minimum speed and apex, the point with the highest lateral acceleration.
+		maxLat := -1.0
+		for _, pct := range scanRange(seg.Start, seg.End) {
+			speed, _ := lap.At("Speed", pct)
+			if speed < s.MinSpeed {
+				s.MinSpeed = speed
+			}
+			lat, _ := lap.At("LatAccel", pct)
+			if math.Abs(lat) > maxLat {
+				maxLat = math.Abs(lat)
+				s.Apex = pct
+				s.ApexSpeed = speed
+			}
+		}
+
+		// First brake application on the way to the apex.
+		for _, pct := range scanRange(prevEnd, s.Apex) {
+			if brake, _ := lap.At("Brake", pct); brake > brakeThreshold {
+				s.BrakePoint = pct
+				break
+			}
+		}
+
+		// First throttle application after the apex.
+		for _, pct := range scanRange(s.Apex, seg.End) {
+			if throttle, _ := lap.At("Throttle", pct); throttle > throttleThreshold {
+				s.ThrottlePickup = pct
+				break
+			}
+		}
+
+		s.Time = segmentTime(lap, seg)
+		if ref != nil {
+			s.TimeLost = s.Time - segmentTime(ref, seg)
+		}
+
+		stats = append(stats, s)
+		prevEnd = seg.End
+	}
+
+	return stats
+}
+
+// Return the positions between start and end at the scan resolution.
+func scanRange(start float64, end float64) []float64 {
+	var positions []float64
+	for pct := start; pct <= end; pct += 1.0 / cornerScanPoints {
+		positions = append(positions, pct)
+	}
+	return positions
+}
+
+func segmentTime(lap *RecordedLap, seg TrackSegment) float64 {
+	start, _ := lap.ElapsedAt(seg.Start)
+	end, _ := lap.ElapsedAt(seg.End)
+	return end - start
+}
@@ -3,7 +3,7 @@ package irsdk
 const (
 	DataValidEventName = "Local\\IRSDKDataValidEvent"
 	MemMapFile         = "Local\\IRSDKMemMapFileName"
-	BroadcastMsgName   = "BROADCASTMSG"
+	BroadcastMsgName   = "IRSDK_BROADCASTMSG"
 	MemMapSize         = 1164 * 1024
 	MaxBufs            = 4
 	MaxString          = 32
@@ -124,6 +124,15 @@ const (
 	TrackWetness_ExtremelyWet
 )
 
+type Skies int
+
+const (
+	SkiesClear Skies = iota
+	SkiesPartlyCloudy
+	SkiesMostlyCloudy
+	SkiesOvercast
+)
+
 type EngineWarnings int
 
 const (
@@ -136,34 +145,39 @@ const (
 	oilTempWarning
 )
 
-type Flags int
+type Flags uint32
+
+const (
+	FlagCheckered Flags = 1 << iota
+	FlagWhite
+	FlagGreen
+	FlagYellow
+	FlagRed
+	FlagBlue
+	FlagDebris
+	FlagCrossed
+	FlagYellowWaving
+	FlagOneLapToGreen
+	FlagGreenHeld
+	FlagTenToGo
+	FlagFiveToGo
+	FlagRandomWaving
+	FlagCaution
+	FlagCautionWaving
+	FlagBlack
+	FlagDisqualify
+	FlagServicible
+	FlagFurled
+	FlagRepair
+	FlagDQScoringInvalid
+)
 
+// Start lights, not contiguous with the other flags.
 const (
-	checkered Flags = 1 << iota
-	white
-	green
-	yellow
-	red
-	blue
-	debris
-	crossed
-	yellowWaving
-	oneLapToGreen
-	greenHeld
-	tenToGo
-	fiveToGo
-	randomWaving
-	caution
-	cautionWaving
-	black
-	disqualify
-	servicible
-	furled
-	repair
-	startHidden
-	startReady
-	startSet
-	startGo
+	FlagStartHidden Flags = 0x10000000 << iota
+	FlagStartReady
+	FlagStartSet
+	FlagStartGo
 )
 
 type CameraState int
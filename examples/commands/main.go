@@ -10,10 +10,17 @@ import (
 func main() {
 	fmt.Println("Not implemented yet")
 
-	// var sdk irsdk.IRSDK
-	// sdk = irsdk.Init(nil)
+	// sdk := irsdk.Init(nil)
 	// defer sdk.Close()
 
+	// b, err := irsdk.NewBroadcaster()
+	// if err != nil {
+	// 	log.Fatal(err)
+	// }
+	// chat := irsdk.NewChatController(b)
+	// pit := irsdk.NewPitService(sdk, b)
+	// ffb := irsdk.NewFFBController(sdk, b)
+
 	// fmt.Println("Available commands:")
 	// fmt.Println(" c -> Open chat")
 	// fmt.Println(" p -> Clear tire pit checkboxes")
@@ -27,30 +34,18 @@ func main() {
 	// 	case 27: // esc
 	// 		os.Exit(0)
 	// 	case 99: // c
-	// 		sdk.BroadcastMsg(irsdk.Msg{
-	// 			Cmd: irsdk.BroadcastChatComand,
-	// 			P1:  irsdk.ChatCommandBeginChat,
-	// 		})
+	// 		chat.Begin()
 	// 		fmt.Println("* Send request to start a chat")
 	// 	case 112: // p
-	// 		sdk.BroadcastMsg(irsdk.Msg{
-	// 			Cmd: irsdk.BroadcastPitCommand,
-	// 			P1:  irsdk.PitCommandClearTires,
-	// 		})
+	// 		pit.ClearTyres()
 	// 		fmt.Println("* Send request to clear tire checkboxes")
 	// 	case 102: // f
-	// 		var force float64
+	// 		force := 20.9998
 	// 		if trueFFBState {
-	// 			force = -1.0
-	// 		} else {
-	// 			force = 20.9998
+	// 			force = irsdk.FFBUserControlled
 	// 		}
 	// 		trueFFBState = !trueFFBState
-	// 		sdk.BroadcastMsg(irsdk.Msg{
-	// 			Cmd: irsdk.BroadcastFFBCommand,
-	// 			P1:  irsdk.FFBCommandMaxForce,
-	// 			P2:  force,
-	// 		})
+	// 		ffb.SetMaxForce(force)
 	// 		if force < 0 {
 	// 			fmt.Println("* Set wheel to user controlled FFB")
 	// 		} else {
@@ -163,10 +163,10 @@ func checkErr(err error) {
 func getRPMData(sdl *irsdk.IRSDK) (rpmLights, error) {
 	session := sdl.Session
 
-	first := fmt.Sprintf("%d", session.DriverInfo.DriverCarSLFirstRPM)
-	last := fmt.Sprintf("%d", session.DriverInfo.DriverCarSLLastRPM)
-	blink := fmt.Sprintf("%d", session.DriverInfo.DriverCarSLBlinkRPM)
-	shift := fmt.Sprintf("%d", session.DriverInfo.DriverCarSLShiftRPM)
+	first := fmt.Sprintf("%.0f", session.DriverInfo.DriverCarSLFirstRPM)
+	last := fmt.Sprintf("%.0f", session.DriverInfo.DriverCarSLLastRPM)
+	blink := fmt.Sprintf("%.0f", session.DriverInfo.DriverCarSLBlinkRPM)
+	shift := fmt.Sprintf("%.0f", session.DriverInfo.DriverCarSLShiftRPM)
 
 	return rpmLights{first, last, blink, shift}, nil
 }
@@ -10,11 +10,22 @@ func main() {
 	sdk := irsdk.Init(nil)
 	defer sdk.Close()
 
-	lapTimes := sdk.Telemetry["CarIdxLastLapTime"]
+	standings := irsdk.NewStandings()
+	standings.Update(sdk)
 
-	session := sdk.Session
+	for _, class := range standings.Classes {
+		fmt.Println(class.ClassName)
 
-	for i := range session.DriverInfo.Drivers {
-		fmt.Println(session.DriverInfo.Drivers[i].CarNumber, irsdk.FloatToTimeStr(lapTimes.Array().([]float32)[i]))
+		for _, car := range class.Cars {
+			fmt.Printf(
+				"%3d #%-4s %-24s %8.3f %8.3f %s\n",
+				car.ClassPosition,
+				car.CarNumber,
+				car.DriverName,
+				car.GapToLeader,
+				car.Interval,
+				irsdk.DoubleToTimeStr(car.LastLapTime),
+			)
+		}
 	}
 }
@@ -0,0 +1,112 @@
+package irsdk
+
+import (
+	"fmt"
+	"math"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Pass it to SetMaxForce to give the force feedback back to the user settings.
+const FFBUserControlled = -1
+
+// Largest force that fits the 16.16 fixed point value of the broadcast message.
+const ffbMaxForce = math.MaxInt32 / 65536.0
+
+// FFBProfile sets the maximum force of a car.
+type FFBProfile struct {
+	CarPath  string  `yaml:"CarPath"`
+	MaxForce float64 `yaml:"MaxForce"` // Nm
+}
+
+// FFBController sets the maximum force used when mapping the steering torque
+// to the wheel. Call Update on every tick to apply the car profiles.
+type FFBController struct {
+	sdk         *IRSDK
+	broadcaster Broadcaster
+
+	// Profiles by CarPath.
+	Profiles map[string]FFBProfile
+
+	carPath string
+	applied bool
+}
+
+func NewFFBController(sdk *IRSDK, b Broadcaster) *FFBController {
+	return &FFBController{
+		sdk:         sdk,
+		broadcaster: b,
+		Profiles:    make(map[string]FFBProfile),
+	}
+}
+
+// Load the car profiles from a YAML file containing a list of FFBProfile.
+func (f *FFBController) LoadProfiles(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var profiles []FFBProfile
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		return err
+	}
+
+	for _, p := range profiles {
+		f.Profiles[p.CarPath] = p
+	}
+	return nil
+}
+
+// Set the maximum force in Nm, or FFBUserControlled to restore the user settings.
+func (f *FFBController) SetMaxForce(nm float64) error {
+	if (nm < 0 && nm != FFBUserControlled) || nm > ffbMaxForce {
+		return fmt.Errorf("invalid max force %.2f Nm", nm)
+	}
+	// The sim expects the float as a 16.16 fixed point value.
+	return f.broadcaster.Broadcast(BroadcastFFBCommand, int(FFBCommand_MaxForce), int(math.Round(nm*65536)))
+}
+
+// Give the force feedback back to the user settings.
+func (f *FFBController) Restore() error {
+	return f.SetMaxForce(FFBUserControlled)
+}
+
+// Apply the profile of the player's car when the session data reports a new
+// car. A car without a profile gets the user settings back if the previous
+// car had one. Return true if the max force was changed.
+func (f *FFBController) Update() (bool, error) {
+	if f.sdk.Session == nil {
+		return false, nil
+	}
+
+	info := f.sdk.Session.DriverInfo
+	carPath := ""
+	for _, d := range info.Drivers {
+		if d.CarIdx == info.DriverCarIdx {
+			carPath = d.CarPath
+		}
+	}
+	if carPath == "" || carPath == f.carPath {
+		return false, nil
+	}
+	f.carPath = carPath
+
+	p, ok := f.Profiles[carPath]
+	if !ok {
+		if !f.applied {
+			return false, nil
+		}
+		if err := f.Restore(); err != nil {
+			return false, err
+		}
+		f.applied = false
+		return true, nil
+	}
+	if err := f.SetMaxForce(p.MaxForce); err != nil {
+		return false, err
+	}
+	f.applied = true
+	return true, nil
+}
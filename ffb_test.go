@@ -0,0 +1,100 @@
+package irsdk
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFFBSetMaxForce(t *testing.T) {
+	b := &fakeBroadcaster{}
+	ffb := NewFFBController(&IRSDK{}, b)
+
+	tests := []struct {
+		nm   float64
+		want int
+	}{
+		{FFBUserControlled, -65536},
+		{0, 0},
+		{1, 65536},
+		{20.5, 1343488},
+		{0.25, 16384},
+	}
+
+	for _, tt := range tests {
+		if err := ffb.SetMaxForce(tt.nm); err != nil {
+			t.Fatal(err)
+		}
+		want := sentMsg{BroadcastFFBCommand, int(FFBCommand_MaxForce), tt.want}
+		if got := b.last(t); got != want {
+			t.Errorf("%.2f Nm: got %v, want %v", tt.nm, got, want)
+		}
+	}
+
+	if err := ffb.SetMaxForce(ffbMaxForce); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.last(t); got.var2 != math.MaxInt32 {
+		t.Errorf("max force: got %v, want %d", got, math.MaxInt32)
+	}
+
+	for _, nm := range []float64{-2, 32768, 1e6} {
+		if err := ffb.SetMaxForce(nm); err == nil {
+			t.Errorf("%.2f Nm: expected an error", nm)
+		}
+	}
+	if len(b.sent) != 0 {
+		t.Errorf("invalid forces sent %v", b.sent)
+	}
+}
+
+func TestFFBProfileOnCarChange(t *testing.T) {
+	b := &fakeBroadcaster{}
+	sdk := &IRSDK{Session: &Session{DriverInfo: DriverInfo{
+		DriverCarIdx: 1,
+		Drivers:      []Driver{{CarIdx: 0, CarPath: "mx5"}, {CarIdx: 1, CarPath: "gt3"}},
+	}}}
+	ffb := NewFFBController(sdk, b)
+	ffb.Profiles["gt3"] = FFBProfile{CarPath: "gt3", MaxForce: 10}
+
+	applied, err := ffb.Update()
+	if err != nil || !applied {
+		t.Fatalf("expected the profile to be applied, got %v %v", applied, err)
+	}
+	want := sentMsg{BroadcastFFBCommand, int(FFBCommand_MaxForce), 655360}
+	if got := b.last(t); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Same car, nothing to do.
+	if applied, _ := ffb.Update(); applied || len(b.sent) != 0 {
+		t.Errorf("profile applied again for the same car")
+	}
+
+	// A car without a profile gets the user settings back.
+	sdk.Session.DriverInfo.DriverCarIdx = 0
+	if applied, err := ffb.Update(); err != nil || !applied {
+		t.Fatalf("expected the user settings to be restored, got %v %v", applied, err)
+	}
+	want = sentMsg{BroadcastFFBCommand, int(FFBCommand_MaxForce), -65536}
+	if got := b.last(t); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Another car without a profile, the user settings are already in place.
+	sdk.Session.DriverInfo.Drivers[0].CarPath = "f3"
+	if applied, _ := ffb.Update(); applied || len(b.sent) != 0 {
+		t.Errorf("user settings restored twice")
+	}
+}
+
+func TestFFBNoProfileWithoutPreviousProfile(t *testing.T) {
+	b := &fakeBroadcaster{}
+	sdk := &IRSDK{Session: &Session{DriverInfo: DriverInfo{
+		Drivers: []Driver{{CarIdx: 0, CarPath: "mx5"}},
+	}}}
+	ffb := NewFFBController(sdk, b)
+
+	if applied, _ := ffb.Update(); applied || len(b.sent) != 0 {
+		t.Errorf("user settings sent without any profile applied before")
+	}
+}
@@ -0,0 +1,72 @@
+package irsdk
+
+// Flags reported by FlagWatcher.
+const watchedFlags = FlagGreen | FlagYellow | FlagYellowWaving | FlagCaution | FlagCautionWaving |
+	FlagWhite | FlagCheckered | FlagBlue | FlagBlack | FlagRepair | FlagDisqualify |
+	FlagStartHidden | FlagStartReady | FlagStartSet | FlagStartGo
+
+// FlagEvent is emitted when a single flag is shown or withdrawn.
+// CarIdx is -1 for the session flags.
+type FlagEvent struct {
+	CarIdx      int
+	Flag        Flags
+	On          bool
+	SessionTime float64
+}
+
+// FlagWatcher diffs SessionFlags and CarIdxSessionFlags between ticks.
+// Call Update on every tick.
+type FlagWatcher struct {
+	Session Flags
+	Cars    []Flags
+
+	started bool
+}
+
+func NewFlagWatcher() *FlagWatcher {
+	return &FlagWatcher{}
+}
+
+// Consume the current telemetry and return the flags changed since the previous call.
+// The first call only records the current state.
+func (w *FlagWatcher) Update(sdk *IRSDK) []FlagEvent {
+	now := sdk.varDouble("SessionTime")
+	session := Flags(sdk.varBitField("SessionFlags")) & watchedFlags
+
+	carFlags := sdk.varBitFields("CarIdxSessionFlags")
+	cars := make([]Flags, len(carFlags))
+	for i, f := range carFlags {
+		cars[i] = Flags(f) & watchedFlags
+	}
+
+	var events []FlagEvent
+
+	if w.started {
+		events = diffFlags(events, -1, w.Session, session, now)
+		for i := range cars {
+			events = diffFlags(events, i, at(w.Cars, i), cars[i], now)
+		}
+	}
+
+	w.Session = session
+	w.Cars = cars
+	w.started = true
+
+	return events
+}
+
+func diffFlags(events []FlagEvent, carIdx int, prev Flags, cur Flags, now float64) []FlagEvent {
+	changed := prev ^ cur
+	// Stop when the bit shifts out, changed can have the top bit set.
+	for bit := Flags(1); bit != 0 && bit <= changed; bit <<= 1 {
+		if changed&bit != 0 {
+			events = append(events, FlagEvent{
+				CarIdx:      carIdx,
+				Flag:        bit,
+				On:          cur&bit != 0,
+				SessionTime: now,
+			})
+		}
+	}
+	return events
+}
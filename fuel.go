@@ -0,0 +1,195 @@
+package irsdk
+
+import (
+	"math"
+	"strconv"
+)
+
+const litersPerGallon = 3.78541
+
+// FuelStatus is the fuel strategy of the player's car. Fuel values are in the
+// driver's display units, named by Unit.
+type FuelStatus struct {
+	Unit string
+
+	Level         float64
+	TankCapacity  float64
+	PerLap        float64
+	LapsRemaining float64
+	LapsToFinish  float64
+	FuelToFinish  float64
+	FuelToAdd     float64
+
+	// Number of green flag laps used to compute PerLap. When zero PerLap is
+	// estimated from FuelUsePerHour.
+	SampleLaps int
+}
+
+// FuelCalculator tracks the fuel used by the player's car on every green flag
+// lap and estimates the fuel needed to reach the end of the session.
+// Call Update on every tick.
+type FuelCalculator struct {
+	// Number of laps in the rolling average.
+	Window int
+	// Extra laps of fuel to carry to the finish.
+	MarginLaps float64
+
+	sessionNum   int
+	lastLap      int
+	lapStartFuel float64
+	lapStartTime float64
+	lapClean     bool
+	usage        []float64
+	lapTimes     []float64
+}
+
+func NewFuelCalculator(window int) *FuelCalculator {
+	if window <= 0 {
+		window = 5
+	}
+	return &FuelCalculator{
+		Window:     window,
+		sessionNum: -1,
+		lastLap:    -1,
+	}
+}
+
+// Consume the current telemetry and return the updated fuel status.
+func (f *FuelCalculator) Update(sdk *IRSDK) FuelStatus {
+	sessionNum := sdk.varInt("SessionNum")
+	if sessionNum != f.sessionNum {
+		f.sessionNum = sessionNum
+		f.lastLap = -1
+		f.usage = nil
+		f.lapTimes = nil
+	}
+
+	level := float64(sdk.varFloat("FuelLevel"))
+	now := sdk.varDouble("SessionTime")
+	lap := sdk.varInt("LapCompleted")
+
+	flags := Flags(sdk.varBitField("SessionFlags"))
+	green := flags&(FlagCaution|FlagCautionWaving|FlagYellow|FlagYellowWaving) == 0 && !sdk.varBool("OnPitRoad")
+	f.lapClean = f.lapClean && green
+
+	switch {
+	case f.lastLap < 0 || lap < f.lastLap:
+		f.lapClean = false
+	case lap > f.lastLap:
+		used := f.lapStartFuel - level
+		// Refuelling or a reset during the lap makes the sample useless.
+		if f.lapClean && lap == f.lastLap+1 && used > 0 {
+			f.usage = appendWindow(f.usage, used, f.Window)
+			f.lapTimes = appendWindow(f.lapTimes, now-f.lapStartTime, f.Window)
+		}
+		f.lapClean = green
+	}
+	if lap != f.lastLap {
+		f.lastLap = lap
+		f.lapStartFuel = level
+		f.lapStartTime = now
+	}
+
+	status := FuelStatus{
+		Level:      level,
+		SampleLaps: len(f.usage),
+	}
+
+	lapTime := average(f.lapTimes)
+	if lapTime == 0 && sdk.Session != nil {
+		lapTime = sdk.Session.DriverInfo.DriverCarEstLapTime
+	}
+
+	status.PerLap = average(f.usage)
+	if status.PerLap == 0 && sdk.Session != nil && sdk.Session.DriverInfo.DriverCarFuelKgPerLtr > 0 {
+		// FuelUsePerHour is in kg/h.
+		perHour := float64(sdk.varFloat("FuelUsePerHour")) / sdk.Session.DriverInfo.DriverCarFuelKgPerLtr
+		status.PerLap = perHour * lapTime / 3600
+	}
+
+	if sdk.Session != nil {
+		status.TankCapacity = sdk.Session.DriverInfo.DriverCarFuelMaxLtr
+		if pct := sdk.Session.DriverInfo.DriverCarMaxFuelPct; pct > 0 {
+			status.TankCapacity *= pct
+		}
+	}
+
+	if status.PerLap > 0 {
+		status.LapsRemaining = level / status.PerLap
+	}
+
+	status.LapsToFinish = lapsToFinish(sdk, lapTime)
+	if status.LapsToFinish > 0 && status.PerLap > 0 {
+		status.FuelToFinish = (status.LapsToFinish + f.MarginLaps) * status.PerLap
+		status.FuelToAdd = math.Max(0, status.FuelToFinish-level)
+		if status.TankCapacity > 0 {
+			status.FuelToAdd = math.Min(status.FuelToAdd, status.TankCapacity-level)
+		}
+	}
+
+	return toDisplayUnits(status, sdk.varInt("DisplayUnits"))
+}
+
+// Return the number of laps, including the rest of the current one, that the
+// player still has to drive. Zero means unknown.
+func lapsToFinish(sdk *IRSDK, lapTime float64) float64 {
+	toLine := 1 - float64(sdk.varFloat("LapDistPct"))
+	if toLine < 0 || toLine > 1 {
+		toLine = 1
+	}
+
+	if details := sdk.currentSession(); details != nil {
+		if _, err := strconv.Atoi(details.SessionLaps); err == nil {
+			if remain := sdk.varInt("SessionLapsRemainEx"); remain > 0 && remain < UnlimitedLaps {
+				return float64(remain-1) + toLine
+			}
+		}
+	}
+
+	timeRemain := sdk.varDouble("SessionTimeRemain")
+	if lapTime <= 0 || timeRemain <= 0 || timeRemain >= UnlimitedTime {
+		return 0
+	}
+
+	// After the time expires the lap in progress is completed.
+	afterLine := timeRemain - toLine*lapTime
+	if afterLine <= 0 {
+		return toLine
+	}
+	return toLine + math.Ceil(afterLine/lapTime)
+}
+
+func toDisplayUnits(s FuelStatus, displayUnits int) FuelStatus {
+	if displayUnits == 1 {
+		s.Unit = "L"
+		return s
+	}
+
+	s.Unit = "gal"
+	s.Level /= litersPerGallon
+	s.TankCapacity /= litersPerGallon
+	s.PerLap /= litersPerGallon
+	s.FuelToFinish /= litersPerGallon
+	s.FuelToAdd /= litersPerGallon
+	return s
+}
+
+// Append the value and drop the oldest ones to keep at most size elements.
+func appendWindow(values []float64, v float64, size int) []float64 {
+	values = append(values, v)
+	if len(values) > size {
+		values = values[len(values)-size:]
+	}
+	return values
+}
+
+func average(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
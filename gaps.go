@@ -0,0 +1,188 @@
+package irsdk
+
+import (
+	"math"
+)
+
+// Number of laps of checkpoint history kept for every car.
+const gapHistoryLaps = 3
+
+// GapCalculator records the time at which every car crosses a set of evenly
+// spaced checkpoints around the track and uses them to compute live time gaps
+// between any two cars, in any session type and across classes.
+// Call Update on every tick.
+type GapCalculator struct {
+	Checkpoints int
+
+	sessionNum int
+	now        float64
+	cars       map[int]*gapCar
+}
+
+type gapCar struct {
+	lap   int
+	pct   float64
+	laps  map[int][]float64
+	valid bool
+	// Ticks in a row with a lap different from the sim lap counter.
+	outOfSync int
+}
+
+// Create a gap calculator with the given number of checkpoints per lap.
+func NewGapCalculator(checkpoints int) *GapCalculator {
+	if checkpoints <= 0 {
+		checkpoints = 200
+	}
+	return &GapCalculator{
+		Checkpoints: checkpoints,
+		sessionNum:  -1,
+		cars:        make(map[int]*gapCar),
+	}
+}
+
+// Record the checkpoints crossed by every car since the previous tick.
+func (g *GapCalculator) Update(sdk *IRSDK) {
+	sessionNum := sdk.varInt("SessionNum")
+	if sessionNum != g.sessionNum {
+		g.sessionNum = sessionNum
+		g.cars = make(map[int]*gapCar)
+	}
+
+	now := sdk.varDouble("SessionTime")
+	prevTime := g.now
+	g.now = now
+
+	pcts := sdk.varFloats("CarIdxLapDistPct")
+	laps := sdk.varInts("CarIdxLap")
+
+	for idx, p := range pcts {
+		pct := float64(p)
+		lap := at(laps, idx)
+		car, ok := g.cars[idx]
+
+		if pct < 0 {
+			if ok {
+				car.valid = false
+			}
+			continue
+		}
+		if !ok {
+			car = &gapCar{laps: make(map[int][]float64)}
+			g.cars[idx] = car
+		}
+		if !car.valid || now <= prevTime {
+			car.lap, car.pct, car.valid = lap, pct, true
+			continue
+		}
+
+		wrapped := car.pct-pct > 0.5
+		end := pct
+		if wrapped {
+			end += 1
+		}
+		if end < car.pct || end-car.pct > maxLapDistPctJump {
+			// Teleported or going backwards: the crossings can't be trusted.
+			car.lap, car.pct = lap, pct
+			continue
+		}
+
+		n := float64(g.Checkpoints)
+		first := math.Floor(car.pct*n) + 1
+		for c := first; c <= end*n; c++ {
+			pos := c / n
+			t := prevTime + (now-prevTime)*(pos-car.pct)/(end-car.pct)
+			cpLap := car.lap
+			if pos >= 1 {
+				cpLap++
+			}
+			car.record(cpLap, int(c)%g.Checkpoints, t, g.Checkpoints)
+		}
+
+		// A position of exactly 1 is the line, the lap changes on the wrap.
+		if wrapped {
+			car.lap++
+		}
+		// Follow the sim lap counter if it disagrees for more than a tick,
+		// it can lag the position by one.
+		if lap >= 0 && lap != car.lap {
+			car.outOfSync++
+			if car.outOfSync > 1 {
+				car.lap = lap
+				car.outOfSync = 0
+			}
+		} else {
+			car.outOfSync = 0
+		}
+		car.pct = pct
+	}
+}
+
+func (c *gapCar) record(lap int, checkpoint int, t float64, checkpoints int) {
+	times, ok := c.laps[lap]
+	if !ok {
+		times = make([]float64, checkpoints)
+		for i := range times {
+			times[i] = math.NaN()
+		}
+		c.laps[lap] = times
+
+		for l := range c.laps {
+			if l <= lap-gapHistoryLaps {
+				delete(c.laps, l)
+			}
+		}
+	}
+	times[checkpoint] = t
+}
+
+// Return the time at which the car was at the given lap and position,
+// interpolating between the two surrounding checkpoints.
+func (g *GapCalculator) timeAt(car *gapCar, lap int, pct float64) (float64, bool) {
+	n := float64(g.Checkpoints)
+	c := int(math.Floor(pct * n))
+
+	times, ok := car.laps[lap]
+	if !ok || math.IsNaN(times[c]) {
+		return 0, false
+	}
+
+	nextLap, next := lap, c+1
+	if next == g.Checkpoints {
+		nextLap, next = lap+1, 0
+	}
+	if nextTimes, ok := car.laps[nextLap]; ok && !math.IsNaN(nextTimes[next]) {
+		frac := pct*n - float64(c)
+		return times[c] + (nextTimes[next]-times[c])*frac, true
+	}
+	return times[c], true
+}
+
+// Return the live gap in seconds between two cars and the number of full laps
+// between them. The values are positive when carB is behind carA and
+// negative when carB is ahead. ok is false if there is not enough history.
+func (g *GapCalculator) Gap(carA int, carB int) (seconds float64, laps int, ok bool) {
+	a, okA := g.cars[carA]
+	b, okB := g.cars[carB]
+	if !okA || !okB || !a.valid || !b.valid {
+		return 0, 0, false
+	}
+
+	sign := 1.0
+	progressA := float64(a.lap) + a.pct
+	progressB := float64(b.lap) + b.pct
+	if progressB > progressA {
+		a, b = b, a
+		progressA, progressB = progressB, progressA
+		sign = -1
+	}
+
+	laps = int(math.Floor(progressA - progressB))
+
+	// Time elapsed since the car ahead was where the car behind is now.
+	t, found := g.timeAt(a, b.lap, b.pct)
+	if !found {
+		return 0, laps * int(sign), false
+	}
+
+	return (g.now - t) * sign, laps * int(sign), true
+}
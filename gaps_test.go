@@ -0,0 +1,101 @@
+package irsdk
+
+import (
+	"math"
+	"testing"
+)
+
+// Progress in laps of the cars in the gap simulation at tick k. Every car
+// covers 0.005 laps per 0.5 s tick, a 100 s lap. Car 1 is 10 s behind car 0
+// and car 2 a lap and 10 s behind.
+func gapProgress(k int) []float64 {
+	p := 1.5 + 0.005*float64(k)
+	return []float64{p, p - 0.1, p - 1.1}
+}
+
+func gapTelemetry(sdk *IRSDK, k int, progress []float64, lastLap []int) {
+	pcts := make([]float32, len(progress))
+	laps := make([]int, len(progress))
+	for i, p := range progress {
+		lap := math.Floor(p + 1e-9)
+		pcts[i] = float32(p - lap)
+		laps[i] = int(lap)
+		// The sim counter lags the position by a tick.
+		if lastLap != nil {
+			laps[i] = lastLap[i]
+		}
+	}
+
+	sdk.Telemetry = map[string]TelemetryVar{
+		"SessionNum":       intVar(0),
+		"SessionTime":      doubleVar(0.5 * float64(k)),
+		"CarIdxLapDistPct": floatVar(pcts...),
+		"CarIdxLap":        intVar(laps...),
+	}
+}
+
+func TestGapCalculatorLineCrossingAndLappedCar(t *testing.T) {
+	sdk := &IRSDK{}
+	g := NewGapCalculator(200)
+
+	var lastLap []int
+	for k := 0; k <= 400; k++ {
+		progress := gapProgress(k)
+		gapTelemetry(sdk, k, progress, lastLap)
+
+		// On the line car 0 reports exactly 1, still on the previous lap.
+		if k == 300 {
+			sdk.Telemetry["CarIdxLapDistPct"] = floatVar(1, float32(progress[1]-2), float32(progress[2]-1))
+		}
+		g.Update(sdk)
+
+		lastLap = make([]int, len(progress))
+		for i, p := range progress {
+			lastLap[i] = int(math.Floor(p + 1e-9))
+		}
+
+		if k >= 40 {
+			seconds, laps, ok := g.Gap(0, 1)
+			if !ok || laps != 0 || math.Abs(seconds-10) > 0.05 {
+				t.Fatalf("tick %d: gap 0-1 = %.3f s %d laps %v, want 10 s", k, seconds, laps, ok)
+			}
+			seconds, laps, ok = g.Gap(1, 0)
+			if !ok || laps != 0 || math.Abs(seconds+10) > 0.05 {
+				t.Fatalf("tick %d: gap 1-0 = %.3f s %d laps %v, want -10 s", k, seconds, laps, ok)
+			}
+		}
+		if k >= 230 {
+			seconds, laps, ok := g.Gap(0, 2)
+			if !ok || laps != 1 || math.Abs(seconds-110) > 0.05 {
+				t.Fatalf("tick %d: gap 0-2 = %.3f s %d laps %v, want 110 s and 1 lap", k, seconds, laps, ok)
+			}
+		}
+	}
+
+	if got := g.cars[0].lap; got != 3 {
+		t.Errorf("lap of car 0 = %d, want 3", got)
+	}
+}
+
+func TestGapCalculatorResync(t *testing.T) {
+	sdk := &IRSDK{}
+	g := NewGapCalculator(200)
+
+	for k := 0; k < 3; k++ {
+		gapTelemetry(sdk, k, gapProgress(k), nil)
+		g.Update(sdk)
+	}
+
+	// A one lap error is fixed once it lasts more than a tick.
+	g.cars[0].lap++
+	gapTelemetry(sdk, 3, gapProgress(3), nil)
+	g.Update(sdk)
+	if got := g.cars[0].lap; got != 2 {
+		t.Errorf("lap after one tick = %d, want 2", got)
+	}
+	gapTelemetry(sdk, 4, gapProgress(4), nil)
+	g.Update(sdk)
+	if got := g.cars[0].lap; got != 1 {
+		t.Errorf("lap after two ticks = %d, want 1", got)
+	}
+}
@@ -35,6 +35,11 @@ func readHeader(r reader) *header {
 		log.Fatal(err)
 	}
 
+	return parseHeader(rbuf)
+}
+
+// Decode the headerSize bytes at the start of the shared memory or of an IBT file.
+func parseHeader(rbuf []byte) *header {
 	h := header{
 		Byte4ToInt(rbuf[0:4]),
 		Byte4ToInt(rbuf[4:8]),
@@ -0,0 +1,136 @@
+package irsdk
+
+import (
+	"fmt"
+	"os"
+)
+
+const diskSubHeaderSize = 32
+
+// Ibt is a telemetry file written by iRacing. The SDK field exposes the
+// session data and, after every call to Next, the telemetry of one record, so
+// that the helpers working on live data can be used on recorded laps.
+type Ibt struct {
+	SDK       *IRSDK
+	SubHeader DiskSubHeader
+
+	headers map[string]varHeader
+	records int
+	next    int
+}
+
+// Open an IBT file and read its session data.
+func OpenIbt(path string) (*Ibt, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ibt, err := readIbt(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return ibt, nil
+}
+
+// Read and validate the headers of an IBT file. Unlike Init, which reads the
+// shared memory, every problem is returned as an error.
+func readIbt(f *os.File) (*Ibt, error) {
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := stat.Size()
+
+	rbuf := make([]byte, headerSize+diskSubHeaderSize)
+	if _, err := f.ReadAt(rbuf, 0); err != nil {
+		return nil, fmt.Errorf("not an IBT file: %w", err)
+	}
+
+	h := parseHeader(rbuf[:headerSize])
+	sub := rbuf[headerSize:]
+	subHeader := DiskSubHeader{
+		int64(Byte8ToInt(sub[0:8])),
+		Byte8ToFloat(sub[8:16]),
+		Byte8ToFloat(sub[16:24]),
+		Byte4ToInt(sub[24:28]),
+		Byte4ToInt(sub[28:32]),
+	}
+
+	switch {
+	case h.BufLen <= 0 || h.NumBuf < 1 || h.NumBuf > MaxBufs:
+		return nil, fmt.Errorf("not an IBT file: invalid buffers")
+	case h.NumVars < 0 || h.VarHeaderOffset < 0 ||
+		int64(h.VarHeaderOffset)+int64(h.NumVars)*varHeaderSize > size:
+		return nil, fmt.Errorf("not an IBT file: invalid variable headers")
+	case h.SessionInfoLen < 0 || h.SessionInfoOffset < 0 ||
+		int64(h.SessionInfoOffset)+int64(h.SessionInfoLen) > size:
+		return nil, fmt.Errorf("not an IBT file: invalid session data")
+	case h.VarBuf[0].BufOffset <= 0 || int64(h.VarBuf[0].BufOffset) > size:
+		return nil, fmt.Errorf("not an IBT file: invalid telemetry offset")
+	}
+
+	rbuf = make([]byte, h.SessionInfoLen)
+	if _, err := f.ReadAt(rbuf, int64(h.SessionInfoOffset)); err != nil {
+		return nil, err
+	}
+	raw, err := decodeSessionData(rbuf)
+	if err != nil {
+		return nil, err
+	}
+	session, err := parseSession(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session data: %w", err)
+	}
+
+	ibt := &Ibt{
+		SDK: &IRSDK{
+			Reader:    f,
+			Header:    h,
+			Telemetry: make(map[string]TelemetryVar),
+			Session:   session,
+		},
+		SubHeader: subHeader,
+		headers:   readVariableHeaders(f, h),
+	}
+
+	for _, v := range ibt.headers {
+		if v.Type < 0 || v.Type >= VarTypeCount || v.Offset < 0 || v.Offset+VarTypeBytes[v.Type]*v.Count > h.BufLen {
+			return nil, fmt.Errorf("not an IBT file: invalid variable %q", v.Name)
+		}
+	}
+
+	// Files of crashed sessions don't have the record count, and the count
+	// can't be trusted beyond the records actually in the file.
+	available := int((size - int64(h.VarBuf[0].BufOffset)) / int64(h.BufLen))
+	ibt.records = subHeader.SessionRecordCount
+	if ibt.records <= 0 || ibt.records > available {
+		ibt.records = available
+	}
+
+	return ibt, nil
+}
+
+// Return the number of telemetry records in the file.
+func (ibt *Ibt) Records() int {
+	return ibt.records
+}
+
+// Load the next record into SDK.Telemetry. It returns false at the end of the file.
+func (ibt *Ibt) Next() bool {
+	if ibt.next >= ibt.records {
+		return false
+	}
+
+	h := ibt.SDK.Header
+	ibt.SDK.Telemetry = readTelemetryBuffer(ibt.SDK.Reader, ibt.headers, h.VarBuf[0].BufOffset+ibt.next*h.BufLen)
+	ibt.next++
+	ibt.SDK.LastTickCount = ibt.next
+
+	return true
+}
+
+func (ibt *Ibt) Close() {
+	ibt.SDK.Close()
+}
@@ -0,0 +1,124 @@
+package irsdk
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Build an IBT file with a single float variable, Speed, and one record per value.
+func buildIbt(session string, speeds []float32) []byte {
+	varHeaderOffset := headerSize + diskSubHeaderSize
+	sessionOffset := varHeaderOffset + varHeaderSize
+	bufOffset := sessionOffset + len(session)
+
+	data := make([]byte, bufOffset+4*len(speeds))
+	put := func(offset int, v int) {
+		binary.LittleEndian.PutUint32(data[offset:], uint32(v))
+	}
+
+	put(0, 2)                       // Version
+	put(8, 60)                      // TickRate
+	put(16, len(session))           // SessionInfoLen
+	put(20, sessionOffset)          // SessionInfoOffset
+	put(24, 1)                      // NumVars
+	put(28, varHeaderOffset)        // VarHeaderOffset
+	put(32, 1)                      // NumBuf
+	put(36, 4)                      // BufLen
+	put(52, bufOffset)              // VarBuf[0].BufOffset
+	put(headerSize+28, len(speeds)) // SessionRecordCount
+
+	put(varHeaderOffset, VarTypeFloat)
+	put(varHeaderOffset+4, 0) // Offset
+	put(varHeaderOffset+8, 1) // Count
+	copy(data[varHeaderOffset+16:], "Speed")
+
+	copy(data[sessionOffset:], session)
+
+	for i, v := range speeds {
+		binary.LittleEndian.PutUint32(data[bufOffset+4*i:], math.Float32bits(v))
+	}
+	return data
+}
+
+func writeTemp(t *testing.T, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.ibt")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestOpenIbt(t *testing.T) {
+	session := "---\nWeekendInfo:\n TrackName: spa\n...\n"
+	ibt, err := OpenIbt(writeTemp(t, buildIbt(session, []float32{1, 2, 3})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ibt.Close()
+
+	if got := ibt.SDK.Session.WeekendInfo.TrackName; got != "spa" {
+		t.Errorf("track = %q", got)
+	}
+	if ibt.Records() != 3 {
+		t.Fatalf("records = %d, want 3", ibt.Records())
+	}
+
+	var speeds []float32
+	for ibt.Next() {
+		speeds = append(speeds, ibt.SDK.varFloat("Speed"))
+	}
+	if len(speeds) != 3 || speeds[0] != 1 || speeds[2] != 3 {
+		t.Errorf("speeds = %v", speeds)
+	}
+}
+
+func TestOpenIbtTruncatedRecords(t *testing.T) {
+	data := buildIbt("---\n...\n", []float32{1, 2, 3})
+	ibt, err := OpenIbt(writeTemp(t, data[:len(data)-6]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ibt.Close()
+
+	if ibt.Records() != 1 {
+		t.Errorf("records = %d, want the 1 complete record", ibt.Records())
+	}
+}
+
+func TestOpenIbtInvalid(t *testing.T) {
+	valid := buildIbt("---\n...\n", []float32{1})
+
+	corrupt := func(offset int, v int) []byte {
+		data := append([]byte(nil), valid...)
+		binary.LittleEndian.PutUint32(data[offset:], uint32(v))
+		return data
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"short", []byte("0123456789")},
+		{"zeros", make([]byte, headerSize+diskSubHeaderSize)},
+		{"no buffers", corrupt(32, 0)},
+		{"no buffer length", corrupt(36, 0)},
+		{"session past the end", corrupt(16, len(valid))},
+		{"variable headers past the end", corrupt(24, 1000)},
+		{"telemetry past the end", corrupt(52, len(valid)+1)},
+		{"variable past the buffer", corrupt(headerSize+diskSubHeaderSize+4, 4)},
+		{"invalid session", buildIbt("---\nDriverInfo:\n\tDriverCarIdx: 1\n", []float32{1})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := OpenIbt(writeTemp(t, tt.data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
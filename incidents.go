@@ -0,0 +1,153 @@
+package irsdk
+
+import (
+	"strconv"
+)
+
+// Incident is an increment of the incident count of a driver, placed at the
+// moment it appeared in the session data.
+type Incident struct {
+	CarIdx      int
+	UserID      int
+	UserName    string
+	Points      int
+	DriverTotal int
+	TeamTotal   int
+
+	SessionTime float64
+	Lap         int
+	LapDistPct  float32
+}
+
+// IncidentTracker diffs the incident counts of the session data every time it
+// is updated and keeps a timeline of incidents for every driver.
+// Call Update on every tick.
+type IncidentTracker struct {
+	// Incidents by UserID, in chronological order.
+	Drivers map[int][]Incident
+
+	sessionNum   int
+	session      *Session
+	driverCounts map[int]int
+	teamCounts   map[int]int
+}
+
+func NewIncidentTracker() *IncidentTracker {
+	t := &IncidentTracker{sessionNum: -1}
+	t.reset()
+	return t
+}
+
+func (t *IncidentTracker) reset() {
+	t.Drivers = make(map[int][]Incident)
+	t.session = nil
+	t.driverCounts = nil
+	t.teamCounts = nil
+}
+
+// Consume the current data and return the incidents found since the previous
+// session update.
+func (t *IncidentTracker) Update(sdk *IRSDK) []Incident {
+	sessionNum := sdk.varInt("SessionNum")
+	if sessionNum != t.sessionNum {
+		t.sessionNum = sessionNum
+		t.reset()
+	}
+
+	// The session data is parsed into a new struct every time it is read.
+	if sdk.Session == nil || sdk.Session == t.session {
+		return nil
+	}
+	t.session = sdk.Session
+
+	now := sdk.varDouble("SessionTime")
+	laps := sdk.varInts("CarIdxLap")
+	pcts := sdk.varFloats("CarIdxLapDistPct")
+
+	info := sdk.Session.DriverInfo
+	driverCounts := make(map[int]int)
+	teamCounts := make(map[int]int)
+
+	for _, d := range info.Drivers {
+		count := d.CurDriverIncidentCount
+		if d.CarIdx == info.DriverCarIdx {
+			count = max(count, info.DriverIncidentCount)
+		}
+		driverCounts[d.UserID] = count
+		teamCounts[d.CarIdx] = d.TeamIncidentCount
+	}
+
+	// The first snapshot is the baseline.
+	if t.driverCounts == nil {
+		t.driverCounts = driverCounts
+		t.teamCounts = teamCounts
+		return nil
+	}
+
+	var incidents []Incident
+
+	for _, d := range info.Drivers {
+		if d.IsSpectator != 0 || d.CarIsPaceCar != 0 {
+			continue
+		}
+
+		points := 0
+		if prev, ok := t.driverCounts[d.UserID]; ok {
+			points = driverCounts[d.UserID] - prev
+		}
+		// Counts of other drivers may be hidden, the team count is still there.
+		if prev, ok := t.teamCounts[d.CarIdx]; ok {
+			points = max(points, teamCounts[d.CarIdx]-prev)
+		}
+		if points <= 0 {
+			continue
+		}
+
+		inc := Incident{
+			CarIdx:      d.CarIdx,
+			UserID:      d.UserID,
+			UserName:    d.UserName,
+			Points:      points,
+			DriverTotal: driverCounts[d.UserID],
+			TeamTotal:   teamCounts[d.CarIdx],
+			SessionTime: now,
+			Lap:         at(laps, d.CarIdx),
+			LapDistPct:  at(pcts, d.CarIdx),
+		}
+		t.Drivers[d.UserID] = append(t.Drivers[d.UserID], inc)
+		incidents = append(incidents, inc)
+	}
+
+	t.driverCounts = driverCounts
+	t.teamCounts = teamCounts
+
+	return incidents
+}
+
+// Return the incident limit of the session, or false if it is unlimited.
+func IncidentLimit(s *Session) (int, bool) {
+	if s == nil {
+		return 0, false
+	}
+	limit, err := strconv.Atoi(s.WeekendInfo.WeekendOptions.IncidentLimit)
+	if err != nil || limit <= 0 {
+		return 0, false
+	}
+	return limit, true
+}
+
+// Return how many incident points the car can still take before reaching the
+// incident limit, or false if the session has no limit.
+func (t *IncidentTracker) ToLimit(sdk *IRSDK, carIdx int) (int, bool) {
+	limit, ok := IncidentLimit(sdk.Session)
+	if !ok {
+		return 0, false
+	}
+	total := t.teamCounts[carIdx]
+	for _, d := range sdk.Session.DriverInfo.Drivers {
+		if d.CarIdx == carIdx {
+			total = max(total, t.driverCounts[d.UserID])
+		}
+	}
+	return limit - total, true
+}
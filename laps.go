@@ -0,0 +1,211 @@
+package irsdk
+
+import (
+	"sort"
+)
+
+// RecordedLap holds the telemetry of one lap of the player's car. Every
+// channel has one value per sample, aligned with Distance (LapDistPct).
+type RecordedLap struct {
+	SessionNum int
+	Number     int
+	StartTime  float64
+	LapTime    float64
+
+	OutLap  bool
+	InLap   bool
+	Invalid bool
+
+	Distance []float64
+	Channels map[string][]float64
+}
+
+// Return true for complete laps driven at racing speed.
+func (l *RecordedLap) IsValid() bool {
+	return !l.OutLap && !l.InLap && !l.Invalid
+}
+
+// Return the value of a channel at the given LapDistPct, interpolating
+// between the two closest samples.
+func (l *RecordedLap) At(channel string, pct float64) (float64, bool) {
+	values, ok := l.Channels[channel]
+	if !ok || len(values) == 0 {
+		return 0, false
+	}
+
+	i := sort.SearchFloat64s(l.Distance, pct)
+	switch {
+	case i == 0:
+		return values[0], true
+	case i >= len(values):
+		return values[len(values)-1], true
+	}
+
+	d0, d1 := l.Distance[i-1], l.Distance[i]
+	if d1 <= d0 {
+		return values[i], true
+	}
+	frac := (pct - d0) / (d1 - d0)
+	return values[i-1] + (values[i]-values[i-1])*frac, true
+}
+
+// LapStore slices the telemetry of the player's car into laps, live or from
+// an IBT file. Call Update on every tick or record.
+type LapStore struct {
+	// Channels to keep, in addition to SessionTime. When empty every scalar
+	// numeric variable is kept.
+	Channels []string
+
+	Laps []*RecordedLap
+
+	current *RecordedLap
+	lastLap int
+	lastPct float64
+}
+
+func NewLapStore(channels ...string) *LapStore {
+	return &LapStore{
+		Channels: channels,
+		lastLap:  -1,
+	}
+}
+
+// Consume the current telemetry and return the lap completed in this tick, if any.
+func (s *LapStore) Update(sdk *IRSDK) *RecordedLap {
+	if !sdk.varBool("IsOnTrack") {
+		// Back to the garage: the lap in progress is lost.
+		s.current = nil
+		s.lastLap = -1
+		return nil
+	}
+
+	lap := sdk.varInt("Lap")
+	pct := float64(sdk.varFloat("LapDistPct"))
+	now := sdk.varDouble("SessionTime")
+	onPitRoad := sdk.varBool("OnPitRoad")
+
+	var done *RecordedLap
+
+	if lap != s.lastLap {
+		if s.current != nil && lap == s.lastLap+1 {
+			done = s.current
+			done.LapTime = now - done.StartTime
+			done.InLap = done.InLap || onPitRoad
+			s.Laps = append(s.Laps, done)
+		}
+
+		s.current = &RecordedLap{
+			SessionNum: sdk.varInt("SessionNum"),
+			Number:     lap,
+			StartTime:  now,
+			OutLap:     onPitRoad,
+			Channels:   make(map[string][]float64),
+		}
+		// Laps joined halfway are not complete.
+		s.current.Invalid = s.lastLap < 0 && pct > maxLapDistPctJump
+		s.lastLap = lap
+		s.lastPct = pct
+	}
+
+	cur := s.current
+	if cur == nil || pct < 0 {
+		return done
+	}
+
+	wrapped := s.lastPct-pct > 0.5
+	if len(cur.Distance) > 0 && !wrapped && (pct < s.lastPct || pct-s.lastPct > maxLapDistPctJump) {
+		// Tows, resets and cut tracks make the lap unusable.
+		cur.Invalid = true
+	}
+	if onPitRoad && !cur.OutLap {
+		cur.InLap = true
+	}
+	surface := TrackSurface(sdk.varInt("PlayerTrackSurfaceMaterial"))
+	if isOffTrackSurface(surface) || TrackLocation(sdk.varInt("PlayerTrackSurface")) == LocationOffTrack {
+		cur.Invalid = true
+	}
+
+	// Lap and LapDistPct don't change on the same tick: drop the samples that
+	// belong to the other side of the line.
+	keep := pct < 0.5
+	if len(cur.Distance) > 0 {
+		keep = pct >= cur.Distance[len(cur.Distance)-1]
+	}
+	if keep {
+		cur.Distance = append(cur.Distance, pct)
+		for name, v := range s.channels(sdk) {
+			cur.Channels[name] = append(cur.Channels[name], v)
+		}
+	}
+	s.lastPct = pct
+
+	return done
+}
+
+// Return the value of every stored channel in the current tick.
+func (s *LapStore) channels(sdk *IRSDK) map[string]float64 {
+	values := make(map[string]float64)
+
+	// SessionTime is always kept to compute the time along the lap.
+	names := append([]string{"SessionTime"}, s.Channels...)
+	if len(s.Channels) == 0 {
+		for name, v := range sdk.Telemetry {
+			if v.Header.Count == 1 && v.Header.Type != VarTypeChar {
+				names = append(names, name)
+			}
+		}
+	}
+
+	for _, name := range names {
+		v, ok := sdk.Telemetry[name]
+		if !ok {
+			continue
+		}
+		values[name] = scalarValue(v)
+	}
+	return values
+}
+
+// Return the value of a scalar variable as a float64.
+func scalarValue(v TelemetryVar) float64 {
+	switch x := v.single().(type) {
+	case bool:
+		if x {
+			return 1
+		}
+	case int:
+		return float64(x)
+	case uint32:
+		return float64(x)
+	case float32:
+		return float64(x)
+	case float64:
+		return x
+	}
+	return 0
+}
+
+// Return the fastest valid lap of a session, or nil if there is none.
+func (s *LapStore) BestLap(sessionNum int) *RecordedLap {
+	var best *RecordedLap
+	for _, l := range s.Laps {
+		if l.SessionNum != sessionNum || !l.IsValid() {
+			continue
+		}
+		if best == nil || l.LapTime < best.LapTime {
+			best = l
+		}
+	}
+	return best
+}
+
+// Return the laps of a session, in the order they were driven.
+func (s *LapStore) SessionLaps(sessionNum int) []*RecordedLap {
+	var laps []*RecordedLap
+	for _, l := range s.Laps {
+		if l.SessionNum == sessionNum {
+			laps = append(laps, l)
+		}
+	}
+	return laps
+}
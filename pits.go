@@ -0,0 +1,150 @@
+package irsdk
+
+type PitEventType int
+
+const (
+	PitEntry PitEventType = iota
+	PitStallArrive
+	PitStallDepart
+	PitExit
+)
+
+// PitEvent is emitted by PitTracker when a car moves through the pit lane.
+// Stop is set on PitExit.
+type PitEvent struct {
+	Type        PitEventType
+	CarIdx      int
+	Lap         int
+	SessionTime float64
+	Stop        *PitStop
+}
+
+// PitStop describes a complete visit to the pit lane. Times are session times
+// in seconds; StallArrive and StallDepart are zero for drive throughs.
+type PitStop struct {
+	CarIdx      int
+	Lap         int
+	Stint       int
+	Entry       float64
+	StallArrive float64
+	StallDepart float64
+	Exit        float64
+
+	StopDuration float64
+	LaneTime     float64
+}
+
+// CarPits is the pit history of a single car.
+type CarPits struct {
+	Stops   int
+	Stint   int
+	History []PitStop
+
+	// Stops made during the current stint, counting drive throughs.
+	StintStops int
+
+	present   bool
+	measuring bool
+	inLane    bool
+	inStall   bool
+	current   PitStop
+}
+
+// PitTracker follows every car through the pit lane using CarIdxOnPitRoad
+// and CarIdxTrackSurface. Call Update on every tick.
+type PitTracker struct {
+	Cars map[int]*CarPits
+
+	sessionNum int
+}
+
+func NewPitTracker() *PitTracker {
+	return &PitTracker{
+		Cars:       make(map[int]*CarPits),
+		sessionNum: -1,
+	}
+}
+
+// Consume the current telemetry and return the pit events since the previous call.
+func (p *PitTracker) Update(sdk *IRSDK) []PitEvent {
+	sessionNum := sdk.varInt("SessionNum")
+	if sessionNum != p.sessionNum {
+		p.sessionNum = sessionNum
+		p.Cars = make(map[int]*CarPits)
+	}
+
+	now := sdk.varDouble("SessionTime")
+	onPitRoad := sdk.varBools("CarIdxOnPitRoad")
+	surface := sdk.varInts("CarIdxTrackSurface")
+	laps := sdk.varInts("CarIdxLap")
+
+	var events []PitEvent
+
+	for idx := range surface {
+		loc := TrackLocation(surface[idx])
+		lap := at(laps, idx)
+
+		car, ok := p.Cars[idx]
+		if !ok {
+			car = &CarPits{Stint: 1}
+			p.Cars[idx] = car
+		}
+
+		if loc == LocationNotInWorld {
+			// The car left the world, the stop in progress can't be measured.
+			car.present = false
+			continue
+		}
+
+		inStall := loc == LocationInPitStall
+		inLane := at(onPitRoad, idx) || inStall || loc == LocationAproachingPits
+
+		if !car.present {
+			// Cars entering the world start in the pits without driving in.
+			car.present = true
+			car.inLane = inLane
+			car.inStall = inStall
+			car.measuring = false
+			continue
+		}
+
+		emit := func(t PitEventType, stop *PitStop) {
+			events = append(events, PitEvent{Type: t, CarIdx: idx, Lap: lap, SessionTime: now, Stop: stop})
+		}
+
+		if inLane && !car.inLane {
+			car.current = PitStop{CarIdx: idx, Lap: lap, Stint: car.Stint, Entry: now}
+			car.measuring = true
+			emit(PitEntry, nil)
+		}
+		if inStall && !car.inStall && car.measuring {
+			car.current.StallArrive = now
+			emit(PitStallArrive, nil)
+		}
+		if !inStall && car.inStall && car.measuring {
+			car.current.StallDepart = now
+			car.current.StopDuration = now - car.current.StallArrive
+			emit(PitStallDepart, nil)
+		}
+		if !inLane && car.inLane && car.measuring {
+			stop := car.current
+			stop.Exit = now
+			stop.LaneTime = now - stop.Entry
+
+			car.History = append(car.History, stop)
+			car.StintStops++
+			if stop.StallArrive > 0 {
+				car.Stops++
+				car.Stint++
+				car.StintStops = 0
+			}
+			car.measuring = false
+			emit(PitExit, &stop)
+		}
+
+		car.inLane = inLane
+		car.inStall = inStall
+	}
+
+	return events
+}
@@ -0,0 +1,176 @@
+package irsdk
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const kPaPerPsi = 6.89476
+
+// PitService requests pit services through broadcast messages. Amounts are in
+// the driver's display units: liters and kPa, or gallons and psi.
+type PitService struct {
+	sdk         *IRSDK
+	broadcaster Broadcaster
+}
+
+func NewPitService(sdk *IRSDK, b Broadcaster) *PitService {
+	return &PitService{sdk: sdk, broadcaster: b}
+}
+
+func (p *PitService) metric() bool {
+	return p.sdk.varInt("DisplayUnits") == 1
+}
+
+func (p *PitService) toLiters(amount float64) float64 {
+	if p.metric() {
+		return amount
+	}
+	return amount * litersPerGallon
+}
+
+func (p *PitService) toKPa(pressure float64) float64 {
+	if p.metric() {
+		return pressure
+	}
+	return pressure * kPaPerPsi
+}
+
+func (p *PitService) command(cmd PitCommandMode, value int) error {
+	return p.broadcaster.Broadcast(BroadcastPitCommand, int(cmd), value)
+}
+
+// Request fuel. Pass 0 to keep the amount already selected.
+func (p *PitService) AddFuel(amount float64) error {
+	if p.sdk.Session == nil {
+		return ErrNoSession
+	}
+
+	liters := p.toLiters(amount)
+	info := p.sdk.Session.DriverInfo
+	capacity := info.DriverCarFuelMaxLtr
+	if info.DriverCarMaxFuelPct > 0 {
+		capacity *= info.DriverCarMaxFuelPct
+	}
+	if liters < 0 || (capacity > 0 && liters > capacity) {
+		return fmt.Errorf("invalid fuel amount %.2f, the tank holds %.2f liters", liters, capacity)
+	}
+
+	return p.command(PitCommand_Fuel, int(math.Round(liters)))
+}
+
+// Request a tyre change. Pass 0 to keep the pressure already selected and a
+// negative value to leave the tyre on the car.
+func (p *PitService) ChangeTyres(lf float64, rf float64, lr float64, rr float64) error {
+	tyres := []struct {
+		cmd      PitCommandMode
+		pressure float64
+	}{
+		{PitCommand_LF, lf},
+		{PitCommand_RF, rf},
+		{PitCommand_LR, lr},
+		{PitCommand_RR, rr},
+	}
+
+	for _, t := range tyres {
+		if t.pressure < 0 {
+			continue
+		}
+		if err := p.command(t.cmd, int(math.Round(p.toKPa(t.pressure)))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Select the tyre compound to fit at the next stop.
+func (p *PitService) TyreCompound(compound int) error {
+	if compound < 0 {
+		return fmt.Errorf("invalid tyre compound %d", compound)
+	}
+	return p.command(PitCommand_TC, compound)
+}
+
+func (p *PitService) FastRepair() error {
+	return p.command(PitCommand_FR, 0)
+}
+
+func (p *PitService) Windshield() error {
+	return p.command(PitCommand_WS, 0)
+}
+
+// Uncheck all the pit services.
+func (p *PitService) Clear() error {
+	return p.command(PitCommand_Clear, 0)
+}
+
+func (p *PitService) ClearTyres() error {
+	return p.command(PitCommand_ClearTires, 0)
+}
+
+func (p *PitService) ClearWindshield() error {
+	return p.command(PitCommand_ClearWS, 0)
+}
+
+func (p *PitService) ClearFastRepair() error {
+	return p.command(PitCommand_ClearFR, 0)
+}
+
+func (p *PitService) ClearFuel() error {
+	return p.command(PitCommand_ClearFuel, 0)
+}
+
+// Wait until all the given services are selected, or none of them with set false.
+func (p *PitService) ConfirmFlags(flags PitSvFlags, set bool, timeout time.Duration) error {
+	return waitTelemetry(p.sdk, func(sdk *IRSDK) bool {
+		cur := PitSvFlags(sdk.varBitField("PitSvFlags")) & flags
+		if set {
+			return cur == flags
+		}
+		return cur == 0
+	}, timeout)
+}
+
+// Wait until the selected fuel amount, in display units, matches. With 0,
+// as for AddFuel, only the fuel request is checked.
+func (p *PitService) ConfirmFuel(amount float64, timeout time.Duration) error {
+	liters := p.toLiters(amount)
+	return waitTelemetry(p.sdk, func(sdk *IRSDK) bool {
+		if PitSvFlags(sdk.varBitField("PitSvFlags"))&FuelFill == 0 {
+			return false
+		}
+		return amount == 0 || math.Abs(float64(sdk.varFloat("PitSvFuel"))-liters) < 1
+	}, timeout)
+}
+
+// Wait until the selected tyre pressures, in display units, match. Negative
+// values are not checked.
+func (p *PitService) ConfirmTyres(lf float64, rf float64, lr float64, rr float64, timeout time.Duration) error {
+	tyres := []struct {
+		flag     PitSvFlags
+		variable string
+		pressure float64
+	}{
+		{LFTireChange, "PitSvLFP", lf},
+		{RFTireChange, "PitSvRFP", rf},
+		{LRTireChange, "PitSvLRP", lr},
+		{RRTireChange, "PitSvRRP", rr},
+	}
+
+	return waitTelemetry(p.sdk, func(sdk *IRSDK) bool {
+		flags := PitSvFlags(sdk.varBitField("PitSvFlags"))
+		for _, t := range tyres {
+			if t.pressure < 0 {
+				continue
+			}
+			if flags&t.flag == 0 {
+				return false
+			}
+			if t.pressure > 0 && math.Abs(float64(sdk.varFloat(t.variable))-p.toKPa(t.pressure)) > 1 {
+				return false
+			}
+		}
+		return true
+	}, timeout)
+}
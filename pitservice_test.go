@@ -0,0 +1,46 @@
+package irsdk
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// Return an sdk whose Update keeps the given telemetry, as a sim that doesn't
+// tick.
+func pitServiceSdk(telemetry map[string]TelemetryVar) *IRSDK {
+	mem := make([]byte, headerSize)
+	return &IRSDK{
+		Reader:    nopCloser{bytes.NewReader(mem)},
+		Telemetry: telemetry,
+	}
+}
+
+func TestPitServiceConfirmFuel(t *testing.T) {
+	tests := []struct {
+		name   string
+		flags  PitSvFlags
+		fuel   float32
+		amount float64
+		ok     bool
+	}{
+		{"keep amount", FuelFill, 12, 0, true},
+		{"keep amount without request", 0, 12, 0, false},
+		{"amount", FuelFill, 30, 30, true},
+		{"other amount", FuelFill, 12, 30, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sdk := pitServiceSdk(map[string]TelemetryVar{
+				"DisplayUnits": intVar(1),
+				"PitSvFlags":   bitFieldVar(uint32(tt.flags)),
+				"PitSvFuel":    floatVar(tt.fuel),
+			})
+			err := NewPitService(sdk, &fakeBroadcaster{}).ConfirmFuel(tt.amount, 20*time.Millisecond)
+			if (err == nil) != tt.ok {
+				t.Errorf("got %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
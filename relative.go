@@ -0,0 +1,120 @@
+package irsdk
+
+import (
+	"math"
+	"sort"
+)
+
+type LapRelation int
+
+const (
+	SameLap LapRelation = iota
+	Lapping             // the car is one or more laps ahead of the focus car
+	Lapped              // the car is one or more laps behind the focus car
+)
+
+// RelativeCar is a car around the focus car on track.
+type RelativeCar struct {
+	CarIdx int
+	Driver Driver
+
+	// Estimated time to reach the car on track in seconds, positive for
+	// cars ahead and negative for cars behind.
+	Delta      float64
+	LapDiff    int
+	Relation   LapRelation
+	ClassColor int
+	OnPitRoad  bool
+	InPitStall bool
+}
+
+// Relative holds the cars closest to the focus car on track, nearest first.
+type Relative struct {
+	Focus  RelativeCar
+	Ahead  []RelativeCar
+	Behind []RelativeCar
+}
+
+// Return the n cars ahead and behind the focus car on track. Use
+// DriverInfo.DriverCarIdx for the player's car or CamCarIdx for the car
+// followed by the camera.
+func (sdk *IRSDK) Relative(focusIdx int, n int) Relative {
+	rel := Relative{}
+	n = max(n, 0)
+
+	cars := sdk.Cars()
+	var focus *Car
+	for i := range cars {
+		if cars[i].CarIdx == focusIdx {
+			focus = &cars[i]
+		}
+	}
+	if focus == nil {
+		return rel
+	}
+
+	lapTime := focus.Driver.CarClassEstLapTime
+	rel.Focus = relativeCar(*focus, 0, 0)
+
+	for _, car := range cars {
+		if car.CarIdx == focusIdx || car.TrackSurface == LocationNotInWorld || car.LapDistPct < 0 {
+			continue
+		}
+
+		// Distance on track between the two cars, between -0.5 and 0.5 laps.
+		dist := float64(car.LapDistPct - focus.LapDistPct)
+		dist -= math.Round(dist)
+
+		delta := dist * lapTime
+		if car.EstTime > 0 && focus.EstTime > 0 && lapTime > 0 {
+			delta = float64(car.EstTime - focus.EstTime)
+			delta -= math.Round(delta/lapTime) * lapTime
+			// Keep the time consistent with the side of the focus car.
+			if (delta > 0) != (dist > 0) {
+				delta = dist * lapTime
+			}
+		}
+
+		progress := float64(car.Lap) + float64(car.LapDistPct)
+		focusProgress := float64(focus.Lap) + float64(focus.LapDistPct)
+		lapDiff := int(math.Round(progress - focusProgress - dist))
+
+		r := relativeCar(car, delta, lapDiff)
+		if dist > 0 {
+			rel.Ahead = append(rel.Ahead, r)
+		} else {
+			rel.Behind = append(rel.Behind, r)
+		}
+	}
+
+	sort.Slice(rel.Ahead, func(i, j int) bool { return rel.Ahead[i].Delta < rel.Ahead[j].Delta })
+	sort.Slice(rel.Behind, func(i, j int) bool { return rel.Behind[i].Delta > rel.Behind[j].Delta })
+
+	if len(rel.Ahead) > n {
+		rel.Ahead = rel.Ahead[:n]
+	}
+	if len(rel.Behind) > n {
+		rel.Behind = rel.Behind[:n]
+	}
+
+	return rel
+}
+
+func relativeCar(car Car, delta float64, lapDiff int) RelativeCar {
+	r := RelativeCar{
+		CarIdx:     car.CarIdx,
+		Driver:     car.Driver,
+		Delta:      delta,
+		LapDiff:    lapDiff,
+		ClassColor: car.Driver.CarClassColor,
+		OnPitRoad:  car.OnPitRoad,
+		InPitStall: car.TrackSurface == LocationInPitStall,
+	}
+	switch {
+	case lapDiff > 0:
+		r.Relation = Lapping
+	case lapDiff < 0:
+		r.Relation = Lapped
+	}
+	return r
+}
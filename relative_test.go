@@ -0,0 +1,18 @@
+package irsdk
+
+import "testing"
+
+func TestRelativeNegativeCount(t *testing.T) {
+	sdk := &IRSDK{
+		Telemetry: map[string]TelemetryVar{
+			"CarIdxLapDistPct":   {varHeader{Type: VarTypeFloat, Count: 2}, []byte{0, 0, 0, 0, 0, 0, 0, 0x3F}},
+			"CarIdxTrackSurface": {varHeader{Type: VarTypeInt, Count: 2}, []byte{3, 0, 0, 0, 3, 0, 0, 0}},
+		},
+		Session: &Session{DriverInfo: DriverInfo{Drivers: []Driver{{CarIdx: 0}, {CarIdx: 1}}}},
+	}
+
+	rel := sdk.Relative(0, -1)
+	if rel.Focus.CarIdx != 0 || len(rel.Ahead) != 0 || len(rel.Behind) != 0 {
+		t.Errorf("unexpected relative %+v", rel)
+	}
+}
@@ -0,0 +1,91 @@
+package irsdk
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReplayController drives the sim replay through broadcast messages.
+type ReplayController struct {
+	sdk         *IRSDK
+	broadcaster Broadcaster
+}
+
+func NewReplayController(sdk *IRSDK, b Broadcaster) *ReplayController {
+	return &ReplayController{sdk: sdk, broadcaster: b}
+}
+
+// Play the replay at the given speed. With slowMo the speed is the divisor
+// of the normal speed, e.g. 2 plays at half speed. Negative speeds rewind
+// and 0 pauses.
+func (r *ReplayController) Play(speed int, slowMo bool) error {
+	slow := 0
+	if slowMo {
+		if speed == 0 {
+			return fmt.Errorf("invalid slow motion speed 0")
+		}
+		slow = 1
+	}
+	return r.broadcaster.Broadcast(BroadcastReplaySetPlaySpeed, speed, makeLong(slow, 0))
+}
+
+func (r *ReplayController) Pause() error {
+	return r.Play(0, false)
+}
+
+// Move to a frame relative to the beginning, the current frame or the end.
+func (r *ReplayController) Seek(frame int, mode RpyPosMode) error {
+	if mode < RpyPos_Begin || mode >= RpyPos_Last {
+		return fmt.Errorf("invalid replay position mode %d", mode)
+	}
+	// The frame number is split across the low and high words of var2.
+	return r.broadcaster.Broadcast(BroadcastReplaySetPlayPosition, int(mode), makeLong(frame&0xFFFF, frame>>16))
+}
+
+// Jump to the given time of a session.
+func (r *ReplayController) SearchSessionTime(sessionNum int, t time.Duration) error {
+	if sessionNum < 0 || t < 0 {
+		return fmt.Errorf("invalid session %d or time %s", sessionNum, t)
+	}
+	ms := int(t.Milliseconds())
+	return r.broadcaster.Broadcast(BroadcastReplaySearchSessionTime, sessionNum, makeLong(ms&0xFFFF, ms>>16))
+}
+
+func (r *ReplayController) Search(mode RpySrchMode) error {
+	if mode < RpySrch_ToStart || mode >= RpySrch_Last {
+		return fmt.Errorf("invalid replay search mode %d", mode)
+	}
+	return r.broadcaster.Broadcast(BroadcastReplaySearch, int(mode), 0)
+}
+
+func (r *ReplayController) ToStart() error      { return r.Search(RpySrch_ToStart) }
+func (r *ReplayController) ToEnd() error        { return r.Search(RpySrch_ToEnd) }
+func (r *ReplayController) PrevSession() error  { return r.Search(RpySrch_PrevSession) }
+func (r *ReplayController) NextSession() error  { return r.Search(RpySrch_NextSession) }
+func (r *ReplayController) PrevLap() error      { return r.Search(RpySrch_PrevLap) }
+func (r *ReplayController) NextLap() error      { return r.Search(RpySrch_NextLap) }
+func (r *ReplayController) PrevFrame() error    { return r.Search(RpySrch_PrevFrame) }
+func (r *ReplayController) NextFrame() error    { return r.Search(RpySrch_NextFrame) }
+func (r *ReplayController) PrevIncident() error { return r.Search(RpySrch_PrevIncident) }
+func (r *ReplayController) NextIncident() error { return r.Search(RpySrch_NextIncident) }
+
+// Clear the replay tape.
+func (r *ReplayController) EraseTape() error {
+	return r.broadcaster.Broadcast(BroadcastReplaySetState, int(RpyState_EraseTape), 0)
+}
+
+// Update the telemetry until cond returns true or the timeout expires.
+// Use it to confirm a command through ReplayFrameNum or IsReplayPlaying.
+func (r *ReplayController) Wait(cond func(sdk *IRSDK) bool, timeout time.Duration) error {
+	return waitTelemetry(r.sdk, cond, timeout)
+}
+
+// Wait until the replay is on the given frame.
+func (r *ReplayController) WaitFrame(frame int, timeout time.Duration) error {
+	return r.Wait(func(sdk *IRSDK) bool { return sdk.varInt("ReplayFrameNum") == frame }, timeout)
+}
+
+// Wait until the replay is playing or paused.
+func (r *ReplayController) WaitPlaying(playing bool, timeout time.Duration) error {
+	return r.Wait(func(sdk *IRSDK) bool { return sdk.varBool("IsReplayPlaying") == playing }, timeout)
+}
@@ -0,0 +1,18 @@
+package irsdk
+
+import "testing"
+
+func TestReplaySeekPacksFrame(t *testing.T) {
+	b := &fakeBroadcaster{}
+	replay := NewReplayController(&IRSDK{}, b)
+
+	for _, frame := range []int{0, 1, 0xFFFF, 0x10000, 123456, -1, -2, -123456} {
+		if err := replay.Seek(frame, RpyPos_Current); err != nil {
+			t.Fatal(err)
+		}
+		want := sentMsg{BroadcastReplaySetPlayPosition, int(RpyPos_Current), frame}
+		if got := b.last(t); got != want {
+			t.Errorf("frame %d: got %v, want %v", frame, got, want)
+		}
+	}
+}
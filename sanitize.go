@@ -0,0 +1,85 @@
+package irsdk
+
+import (
+	"strings"
+)
+
+// Session string keys whose values are free text typed by users or league
+// admins. iRacing writes them without any quoting, so a name such as
+// "Team: Red" or "'Bob' Smith" is enough to make the YAML parser fail.
+var sessionFreeTextKeys = map[string]bool{
+	"UserName":              true,
+	"TeamName":              true,
+	"AbbrevName":            true,
+	"Initials":              true,
+	"FrequencyName":         true,
+	"CarScreenName":         true,
+	"CarScreenNameShort":    true,
+	"CarClassShortName":     true,
+	"TrackDisplayName":      true,
+	"TrackDisplayShortName": true,
+	"TrackConfigName":       true,
+	"TrackCity":             true,
+	"TrackCountry":          true,
+	"SessionName":           true,
+	"DriverSetupName":       true,
+	"GroupName":             true,
+	"CameraName":            true,
+}
+
+// Quote the values of the known free text keys so that the session string can
+// be parsed by a strict YAML parser.
+func sanitizeSessionYaml(in string) string {
+	lines := strings.Split(in, "\n")
+
+	for i, line := range lines {
+		lines[i] = sanitizeSessionLine(line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func sanitizeSessionLine(line string) string {
+	// Lines have the form "<indent>[- ]Key: value".
+	body := strings.TrimLeft(line, " ")
+	body = strings.TrimPrefix(body, "- ")
+
+	sep := strings.Index(body, ": ")
+	if sep <= 0 {
+		return line
+	}
+
+	key := body[:sep]
+	if !sessionFreeTextKeys[key] {
+		return line
+	}
+
+	prefix := line[:len(line)-len(body)+sep+2]
+	value := strings.TrimRight(body[sep+2:], " \r")
+	if value == "" || isQuotedScalar(value) {
+		return line
+	}
+
+	return prefix + quoteScalar(value)
+}
+
+// Report whether the value is a double quoted number, like the ones iRacing
+// emits for car numbers. Other double quoted values come from users and may
+// hold backslashes that YAML would read as escapes.
+func isQuotedScalar(value string) bool {
+	if len(value) < 3 || value[0] != '"' || value[len(value)-1] != '"' {
+		return false
+	}
+	for _, c := range value[1 : len(value)-1] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Wrap the value in single quotes, the only YAML style where every
+// character except the quote itself is taken literally.
+func quoteScalar(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
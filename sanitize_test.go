@@ -0,0 +1,146 @@
+package irsdk
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestSanitizeSessionYamlNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain", "Bob Smith", "Bob Smith"},
+		{"colon", "Team: Red", "Team: Red"},
+		{"single quotes", "'Bob' Smith", "'Bob' Smith"},
+		{"double quotes", `"Bob" Smith`, `"Bob" Smith`},
+		{"two double quoted words", `"Bob" "Smith"`, `"Bob" "Smith"`},
+		{"double quoted", `"Bob Smith"`, `"Bob Smith"`},
+		{"double quoted number", `"007"`, "007"},
+		{"backslash", `AC\DC`, `AC\DC`},
+		{"double quoted backslash", `"AC\DC"`, `"AC\DC"`},
+		{"double quoted escape", `"Bob\n"`, `"Bob\n"`},
+		{"alias", "*Bob", "*Bob"},
+		{"anchor", "&Bob", "&Bob"},
+		{"tag", "!Bob", "!Bob"},
+		{"reserved at", "@Bob", "@Bob"},
+		{"directive", "%Bob", "%Bob"},
+		{"comment", "Bob #1", "Bob #1"},
+		{"comment only", "#1", "#1"},
+		{"sequence", "- Bob", "- Bob"},
+		{"flow", "[Bob] {Smith}", "[Bob] {Smith}"},
+		{"number", "0012", "0012"},
+		{"boolean", "yes", "yes"},
+		{"trailing carriage return", "Bob Smith\r", "Bob Smith"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := "---\n" +
+				"DriverInfo:\n" +
+				" DriverCarIdx: 0\n" +
+				" Drivers:\n" +
+				" - CarIdx: 0\n" +
+				"   UserName: " + tt.value + "\n" +
+				"   TeamName: " + tt.value + "\n" +
+				"   CarNumber: \"007\"\n" +
+				"   CarScreenName: Mazda MX-5\n" +
+				"...\n"
+
+			var s Session
+			if err := yaml.Unmarshal([]byte(sanitizeSessionYaml(raw)), &s); err != nil {
+				t.Fatalf("unmarshal: %v\n%s", err, sanitizeSessionYaml(raw))
+			}
+			if len(s.DriverInfo.Drivers) != 1 {
+				t.Fatalf("expected 1 driver, got %d", len(s.DriverInfo.Drivers))
+			}
+
+			d := s.DriverInfo.Drivers[0]
+			if d.UserName != tt.want || d.TeamName != tt.want {
+				t.Errorf("got %q / %q, want %q", d.UserName, d.TeamName, tt.want)
+			}
+			if d.CarNumber != "007" {
+				t.Errorf("car number = %q, want %q", d.CarNumber, "007")
+			}
+			if d.CarScreenName != "Mazda MX-5" {
+				t.Errorf("car screen name = %q", d.CarScreenName)
+			}
+		})
+	}
+}
+
+func TestSanitizeSessionYamlCRLF(t *testing.T) {
+	raw := strings.Join([]string{
+		"---",
+		"WeekendInfo:",
+		" TrackDisplayName: Circuit de Spa: Francorchamps",
+		" TrackConfigName: ",
+		"DriverInfo:",
+		" Drivers:",
+		" - CarIdx: 0",
+		"   UserName: *Bob* #1",
+		"   TeamName:",
+		" - CarIdx: 1",
+		"   UserName: 'Ann'",
+		"   TeamName: \"Team: Blue\"",
+		"   CarNumber: \"07\"",
+		"...",
+		"",
+	}, "\r\n")
+
+	var s Session
+	if err := yaml.Unmarshal([]byte(sanitizeSessionYaml(raw)), &s); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := s.WeekendInfo.TrackDisplayName; got != "Circuit de Spa: Francorchamps" {
+		t.Errorf("track = %q", got)
+	}
+	if got := s.WeekendInfo.TrackConfigName; got != "" {
+		t.Errorf("track config = %q", got)
+	}
+
+	want := []struct{ user, team string }{
+		{"*Bob* #1", ""},
+		{"'Ann'", `"Team: Blue"`},
+	}
+	if len(s.DriverInfo.Drivers) != len(want) {
+		t.Fatalf("expected %d drivers, got %d", len(want), len(s.DriverInfo.Drivers))
+	}
+	for i, w := range want {
+		d := s.DriverInfo.Drivers[i]
+		if d.UserName != w.user || d.TeamName != w.team {
+			t.Errorf("driver %d: got %q / %q, want %q / %q", i, d.UserName, d.TeamName, w.user, w.team)
+		}
+	}
+	if got := s.DriverInfo.Drivers[1].CarNumber; got != "07" {
+		t.Errorf("car number = %q, want %q", got, "07")
+	}
+}
+
+// nopCloser serves a byte slice as the shared memory.
+type nopCloser struct {
+	*bytes.Reader
+}
+
+func (nopCloser) Close() error { return nil }
+
+func TestUpdateSessionDataKeepsPreviousOnError(t *testing.T) {
+	raw := "---\nDriverInfo:\n\tDriverCarIdx: 1\n...\n"
+	prev := &Session{}
+	sdk := &IRSDK{
+		Reader:  nopCloser{bytes.NewReader([]byte(raw))},
+		Header:  &header{SessionInfoLen: len(raw)},
+		Session: prev,
+	}
+
+	updateSessionData(sdk)
+	if sdk.Session != prev {
+		t.Errorf("session replaced after a parse error")
+	}
+}
@@ -0,0 +1,388 @@
+package irsdk
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ScriptCommand is a single broadcast command of a cue.
+//
+// Action is one of:
+//   - focus: switch the camera to Target ("leader", "incident" or "exiting"),
+//     to the car with number Car or to the car in Position, with optional
+//     camera Group and Camera names
+//   - chat: send the chat Macro, from 1 to 15
+//   - broadcast: send the raw message Msg with Var1 and Var2
+type ScriptCommand struct {
+	Action string `yaml:"Action"`
+	// Seconds to wait after the previous command of the cue.
+	Delay float64 `yaml:"Delay"`
+
+	Target   string `yaml:"Target"`
+	Car      string `yaml:"Car"`
+	Position int    `yaml:"Position"`
+	Group    string `yaml:"Group"`
+	Camera   string `yaml:"Camera"`
+
+	Macro int `yaml:"Macro"`
+
+	Msg  BroadcastMsg `yaml:"Msg"`
+	Var1 int          `yaml:"Var1"`
+	Var2 int          `yaml:"Var2"`
+}
+
+// Cue runs its commands when all the triggers that are set hold. A cue
+// without triggers runs on the first update.
+type Cue struct {
+	Name string `yaml:"Name"`
+
+	// Seconds since the first update of the scheduler.
+	After float64 `yaml:"After"`
+	// Seconds of SessionTime.
+	SessionTime float64 `yaml:"SessionTime"`
+	// Laps completed by the leader, from RaceLaps.
+	Lap int `yaml:"Lap"`
+	// Telemetry condition in the form "<variable> <operator> <value>", e.g.
+	// "SessionFlags & 0x4000". Operators: == != < <= > >= and & for bit fields.
+	Condition string `yaml:"Condition"`
+	// Condition for cues built in code, checked together with the others.
+	Cond func(sdk *IRSDK) bool `yaml:"-"`
+
+	// Run the cue again every time the triggers start to hold, instead of once.
+	Repeat bool `yaml:"Repeat"`
+
+	Commands []ScriptCommand `yaml:"Commands"`
+
+	condition func(sdk *IRSDK) bool
+	active    bool
+	done      bool
+}
+
+// CueEvent reports a command run by the scheduler.
+type CueEvent struct {
+	Cue     string
+	Command ScriptCommand
+	Time    time.Time
+	// Message built for the command, not sent in dry run mode.
+	Msg    BroadcastMsg
+	Var1   int
+	Var2   int
+	DryRun bool
+	Err    error
+}
+
+type queuedCommand struct {
+	cue     string
+	command ScriptCommand
+	due     time.Time
+}
+
+// Scheduler runs cue lists of broadcast commands. Call Update on every tick.
+type Scheduler struct {
+	sdk         *IRSDK
+	broadcaster Broadcaster
+
+	// Minimum time between two messages sent to the sim.
+	MinInterval time.Duration
+	// Resolve the commands and report them without sending anything to the sim.
+	DryRun bool
+
+	cues     []*Cue
+	start    time.Time
+	lastSent time.Time
+	queue    []queuedCommand
+	now      func() time.Time
+}
+
+func NewScheduler(sdk *IRSDK, b Broadcaster) *Scheduler {
+	return &Scheduler{
+		sdk:         sdk,
+		broadcaster: b,
+		MinInterval: 100 * time.Millisecond,
+		now:         time.Now,
+	}
+}
+
+// Load the cues from a YAML file containing a list of Cue.
+func (s *Scheduler) LoadScript(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var cues []*Cue
+	if err := yaml.Unmarshal(data, &cues); err != nil {
+		return err
+	}
+	return s.Add(cues...)
+}
+
+// Validate the cues and add them to the scheduler.
+func (s *Scheduler) Add(cues ...*Cue) error {
+	for _, c := range cues {
+		if c.Condition != "" {
+			cond, err := parseCondition(c.Condition)
+			if err != nil {
+				return fmt.Errorf("cue %q: %w", c.Name, err)
+			}
+			c.condition = cond
+		}
+		for _, cmd := range c.Commands {
+			if err := cmd.validate(); err != nil {
+				return fmt.Errorf("cue %q: %w", c.Name, err)
+			}
+		}
+	}
+	s.cues = append(s.cues, cues...)
+	return nil
+}
+
+// Check the triggers and send the queued commands that are due, earliest first.
+func (s *Scheduler) Update() []CueEvent {
+	now := s.now()
+	if s.start.IsZero() {
+		s.start = now
+	}
+
+	for _, c := range s.cues {
+		if c.done {
+			continue
+		}
+
+		ready := s.triggered(c, now)
+		if ready && !c.active {
+			s.enqueue(c, now)
+			if !c.Repeat {
+				c.done = true
+			}
+		}
+		c.active = ready
+	}
+
+	var events []CueEvent
+	for len(s.queue) > 0 {
+		q := s.queue[0]
+		if now.Before(q.due) || (!s.lastSent.IsZero() && now.Sub(s.lastSent) < s.MinInterval) {
+			break
+		}
+		s.queue = s.queue[1:]
+		e, sent := s.run(q, now)
+		if sent {
+			s.lastSent = now
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+// Return the number of commands waiting to be sent.
+func (s *Scheduler) Pending() int {
+	return len(s.queue)
+}
+
+func (s *Scheduler) triggered(c *Cue, now time.Time) bool {
+	if c.After > 0 && now.Sub(s.start).Seconds() < c.After {
+		return false
+	}
+	if c.SessionTime > 0 && s.sdk.varDouble("SessionTime") < c.SessionTime {
+		return false
+	}
+	if c.Lap > 0 && s.sdk.varInt("RaceLaps") < c.Lap {
+		return false
+	}
+	if c.condition != nil && !c.condition(s.sdk) {
+		return false
+	}
+	if c.Cond != nil && !c.Cond(s.sdk) {
+		return false
+	}
+	return true
+}
+
+func (s *Scheduler) enqueue(c *Cue, now time.Time) {
+	due := now
+	for _, cmd := range c.Commands {
+		due = due.Add(time.Duration(cmd.Delay * float64(time.Second)))
+		s.queue = append(s.queue, queuedCommand{c.Name, cmd, due})
+	}
+	// A delayed command must not hold back the cues triggered after it.
+	sort.SliceStable(s.queue, func(i, j int) bool { return s.queue[i].due.Before(s.queue[j].due) })
+}
+
+// Run a command and return whether it produced a message.
+func (s *Scheduler) run(q queuedCommand, now time.Time) (CueEvent, bool) {
+	rec := &recordingBroadcaster{}
+	if !s.DryRun {
+		rec.next = s.broadcaster
+	}
+
+	err := q.command.run(s.sdk, rec)
+	return CueEvent{
+		Cue:     q.cue,
+		Command: q.command,
+		Time:    now,
+		Msg:     rec.msg,
+		Var1:    rec.var1,
+		Var2:    rec.var2,
+		DryRun:  s.DryRun,
+		Err:     err,
+	}, rec.sent
+}
+
+func (c ScriptCommand) validate() error {
+	switch c.Action {
+	case "focus":
+		switch c.Target {
+		case "", "leader", "incident", "exiting":
+		default:
+			return fmt.Errorf("invalid focus target %q", c.Target)
+		}
+		if c.Target == "" && c.Car == "" && c.Position <= 0 {
+			return fmt.Errorf("focus needs a target, a car or a position")
+		}
+	case "chat":
+		if c.Macro < 1 || c.Macro > 15 {
+			return fmt.Errorf("invalid chat macro %d, must be between 1 and 15", c.Macro)
+		}
+	case "broadcast":
+		if c.Msg < 0 || c.Msg >= BroadcastLast {
+			return fmt.Errorf("invalid broadcast message %d", c.Msg)
+		}
+	default:
+		return fmt.Errorf("unknown action %q", c.Action)
+	}
+	if c.Delay < 0 {
+		return fmt.Errorf("invalid delay %.2f", c.Delay)
+	}
+	return nil
+}
+
+func (c ScriptCommand) run(sdk *IRSDK, b Broadcaster) error {
+	switch c.Action {
+	case "focus":
+		camera := NewCameraController(sdk, b)
+		switch {
+		case c.Target == "leader":
+			return camera.FocusLeader(c.Group, c.Camera)
+		case c.Target == "incident":
+			return camera.FocusIncident(c.Group, c.Camera)
+		case c.Target == "exiting":
+			return camera.FocusExiting(c.Group, c.Camera)
+		case c.Car != "":
+			return camera.FocusCar(c.Car, c.Group, c.Camera)
+		default:
+			return camera.FocusPosition(c.Position, c.Group, c.Camera)
+		}
+	case "chat":
+		return NewChatController(b).Macro(c.Macro)
+	case "broadcast":
+		return b.Broadcast(c.Msg, c.Var1, c.Var2)
+	}
+	return fmt.Errorf("unknown action %q", c.Action)
+}
+
+// recordingBroadcaster keeps the last message and forwards it when next is set.
+// In dry run mode, without next, the message counts as sent.
+type recordingBroadcaster struct {
+	next Broadcaster
+
+	msg  BroadcastMsg
+	var1 int
+	var2 int
+	sent bool
+}
+
+func (r *recordingBroadcaster) Broadcast(msg BroadcastMsg, var1 int, var2 int) error {
+	r.msg, r.var1, r.var2 = msg, var1, var2
+	if r.next == nil {
+		r.sent = true
+		return nil
+	}
+	err := r.next.Broadcast(msg, var1, var2)
+	r.sent = err == nil
+	return err
+}
+
+// Parse a condition in the form "<variable> <operator> <value>".
+func parseCondition(expr string) (func(sdk *IRSDK) bool, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("invalid condition %q", expr)
+	}
+	name, op := fields[0], fields[1]
+
+	value, err := parseConditionValue(fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition %q: %w", expr, err)
+	}
+
+	var cmp func(v float64) bool
+	switch op {
+	case "==":
+		cmp = func(v float64) bool { return v == value }
+	case "!=":
+		cmp = func(v float64) bool { return v != value }
+	case "<":
+		cmp = func(v float64) bool { return v < value }
+	case "<=":
+		cmp = func(v float64) bool { return v <= value }
+	case ">":
+		cmp = func(v float64) bool { return v > value }
+	case ">=":
+		cmp = func(v float64) bool { return v >= value }
+	case "&":
+		cmp = func(v float64) bool { return int64(v)&int64(value) != 0 }
+	default:
+		return nil, fmt.Errorf("invalid operator %q in condition %q", op, expr)
+	}
+
+	return func(sdk *IRSDK) bool {
+		v, ok := sdk.varNumber(name)
+		return ok && cmp(v)
+	}, nil
+}
+
+func parseConditionValue(s string) (float64, error) {
+	switch s {
+	case "true":
+		return 1, nil
+	case "false":
+		return 0, nil
+	}
+	if i, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return float64(i), nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// Return a scalar variable as a number, with booleans as 0 or 1.
+func (sdk *IRSDK) varNumber(name string) (float64, bool) {
+	tv := sdk.Telemetry[name]
+	if tv.Header.Count > 1 {
+		return 0, false
+	}
+	switch v := tv.single().(type) {
+	case int:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case byte:
+		return float64(v), true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,155 @@
+package irsdk
+
+import (
+	"testing"
+	"time"
+)
+
+// Return a scheduler on a fake clock, advanced by the returned function.
+func newTestScheduler(sdk *IRSDK, b Broadcaster) (*Scheduler, func(d time.Duration)) {
+	s := NewScheduler(sdk, b)
+	now := time.Unix(0, 0)
+	s.now = func() time.Time { return now }
+	return s, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestSchedulerDelayDoesNotBlockLaterCues(t *testing.T) {
+	b := &fakeBroadcaster{}
+	s, advance := newTestScheduler(&IRSDK{}, b)
+
+	err := s.Add(
+		&Cue{Name: "a", Commands: []ScriptCommand{{Action: "broadcast", Msg: BroadcastCamSetState, Delay: 30}}},
+		&Cue{Name: "b", Commands: []ScriptCommand{{Action: "broadcast", Msg: BroadcastReplaySearch}}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 10; i++ {
+		s.Update()
+		advance(time.Second)
+	}
+	if got := b.last(t); got.msg != BroadcastReplaySearch {
+		t.Errorf("got %v, want the message of cue b", got)
+	}
+	if s.Pending() != 1 {
+		t.Errorf("pending = %d, want 1", s.Pending())
+	}
+
+	advance(20 * time.Second)
+	s.Update()
+	if got := b.last(t); got.msg != BroadcastCamSetState {
+		t.Errorf("got %v, want the message of cue a", got)
+	}
+}
+
+func TestSchedulerMinInterval(t *testing.T) {
+	b := &fakeBroadcaster{}
+	s, advance := newTestScheduler(&IRSDK{}, b)
+
+	err := s.Add(&Cue{Name: "a", Commands: []ScriptCommand{
+		{Action: "focus", Car: "99"}, // fails, there is no session
+		{Action: "chat", Macro: 1},
+		{Action: "chat", Macro: 2},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The failed command sends nothing, so the first macro goes out right away.
+	events := s.Update()
+	if len(events) != 2 || events[0].Err == nil || events[1].Err != nil {
+		t.Fatalf("unexpected events %+v", events)
+	}
+	b.last(t)
+
+	advance(s.MinInterval / 2)
+	if events := s.Update(); len(events) != 0 {
+		t.Errorf("sent before MinInterval: %+v", events)
+	}
+
+	advance(s.MinInterval / 2)
+	s.Update()
+	if got := b.last(t); got.var2 != 1 {
+		t.Errorf("got %v, want macro 2", got)
+	}
+}
+
+func TestSchedulerDryRun(t *testing.T) {
+	b := &fakeBroadcaster{}
+	s, _ := newTestScheduler(&IRSDK{}, b)
+	s.DryRun = true
+
+	if err := s.Add(&Cue{Name: "a", Commands: []ScriptCommand{{Action: "chat", Macro: 3}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	events := s.Update()
+	if len(b.sent) != 0 {
+		t.Errorf("dry run sent %v", b.sent)
+	}
+	if len(events) != 1 || !events[0].DryRun || events[0].Msg != BroadcastChatComand || events[0].Var2 != 2 {
+		t.Errorf("unexpected events %+v", events)
+	}
+}
+
+func TestSchedulerCondition(t *testing.T) {
+	b := &fakeBroadcaster{}
+	sdk := &IRSDK{Telemetry: map[string]TelemetryVar{}}
+	s, advance := newTestScheduler(sdk, b)
+	s.MinInterval = 0
+
+	err := s.Add(&Cue{
+		Name:      "caution",
+		Condition: "SessionFlags & 0x4000",
+		Repeat:    true,
+		Commands:  []ScriptCommand{{Action: "broadcast", Msg: BroadcastCamSetState}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	setFlags := func(f Flags) {
+		sdk.Telemetry["SessionFlags"] = TelemetryVar{
+			varHeader{Type: VarTypeBitField, Count: 1},
+			[]byte{byte(f), byte(f >> 8), byte(f >> 16), byte(f >> 24)},
+		}
+	}
+
+	steps := []struct {
+		flags Flags
+		sent  int
+	}{
+		{FlagGreen, 0},
+		{FlagCaution, 1},
+		{FlagCaution, 0},
+		{FlagGreen, 0},
+		{FlagCaution | FlagCautionWaving, 1},
+	}
+	for i, step := range steps {
+		setFlags(step.flags)
+		s.Update()
+		if len(b.sent) != step.sent {
+			t.Errorf("step %d: sent %d messages, want %d", i, len(b.sent), step.sent)
+		}
+		b.sent = nil
+		advance(time.Second)
+	}
+}
+
+func TestSchedulerInvalidScript(t *testing.T) {
+	s := NewScheduler(&IRSDK{}, &fakeBroadcaster{})
+
+	cues := []*Cue{
+		{Name: "operator", Condition: "SessionFlags ~ 1"},
+		{Name: "value", Condition: "SessionFlags & caution"},
+		{Name: "action", Commands: []ScriptCommand{{Action: "dance"}}},
+		{Name: "macro", Commands: []ScriptCommand{{Action: "chat", Macro: 16}}},
+		{Name: "target", Commands: []ScriptCommand{{Action: "focus", Target: "winner"}}},
+	}
+	for _, c := range cues {
+		if err := s.Add(c); err == nil {
+			t.Errorf("cue %q: expected an error", c.Name)
+		}
+	}
+}
@@ -9,13 +9,14 @@ import (
 )
 
 type Session struct {
-	WeekendInfo   WeekendInfo   `yaml:"WeekendInfo"`
-	SessionInfo   SessionInfo   `yaml:"SessionInfo"`
-	CameraInfo    CameraInfo    `yaml:"CameraInfo"`
-	RadioInfo     RadioInfo     `yaml:"RadioInfo"`
-	DriverInfo    DriverInfo    `yaml:"DriverInfo"`
-	SplitTimeInfo SplitTimeInfo `yaml:"SplitTimeInfo"`
-	CarSetup      CarSetup      `yaml:"CarSetup"`
+	WeekendInfo        WeekendInfo        `yaml:"WeekendInfo"`
+	SessionInfo        SessionInfo        `yaml:"SessionInfo"`
+	CameraInfo         CameraInfo         `yaml:"CameraInfo"`
+	RadioInfo          RadioInfo          `yaml:"RadioInfo"`
+	DriverInfo         DriverInfo         `yaml:"DriverInfo"`
+	SplitTimeInfo      SplitTimeInfo      `yaml:"SplitTimeInfo"`
+	CarSetup           CarSetup           `yaml:"CarSetup"`
+	QualifyResultsInfo QualifyResultsInfo `yaml:"QualifyResultsInfo"`
 }
 
 type WeekendInfo struct {
@@ -237,6 +238,20 @@ type SessionDetails struct {
 	ResultsOfficial         int                 `yaml:"ResultsOfficial"`
 }
 
+// QualifyResultsInfo holds the qualifying results used to build the grid,
+// also when qualifying was run in a separate event. Positions are zero based.
+type QualifyResultsInfo struct {
+	Results []QualifyResult `yaml:"Results"`
+}
+
+type QualifyResult struct {
+	Position      int     `yaml:"Position"`
+	ClassPosition int     `yaml:"ClassPosition"`
+	CarIdx        int     `yaml:"CarIdx"`
+	FastestLap    int     `yaml:"FastestLap"`
+	FastestTime   float64 `yaml:"FastestTime"`
+}
+
 type ResultsFastestLap struct {
 	CarIdx      int `yaml:"CarIdx"`
 	FastestLap  int `yaml:"FastestLap"`
@@ -348,8 +363,6 @@ type InCarSettings struct {
 }
 
 func readSessionData(sdk *IRSDK) string {
-	dec := charmap.Windows1252.NewDecoder()
-
 	rbuf := make([]byte, sdk.Header.SessionInfoLen)
 
 	_, err := sdk.Reader.ReadAt(rbuf, int64(sdk.Header.SessionInfoOffset))
@@ -357,24 +370,57 @@ func readSessionData(sdk *IRSDK) string {
 		log.Fatal(err)
 	}
 
-	rbuf, err = dec.Bytes(rbuf)
+	yaml, err := decodeSessionData(rbuf)
 	if err != nil {
 		log.Fatal(err)
 	}
-
-	yaml := strings.TrimRight(string(rbuf[:sdk.Header.SessionInfoLen]), "\x00")
 	return yaml
 }
 
-// This function updates the session data in the sdk struct
-func updateSessionData(sdk *IRSDK) {
-	sRaw := readSessionData(sdk)
+// Convert the Windows-1252 session string to UTF-8 and drop the padding.
+func decodeSessionData(rbuf []byte) (string, error) {
+	dec := charmap.Windows1252.NewDecoder()
 
-	newSession := Session{}
-	err := yaml.Unmarshal([]byte(sRaw), &newSession)
+	rbuf, err := dec.Bytes(rbuf)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
+	}
+
+	return strings.TrimRight(string(rbuf), "\x00"), nil
+}
+
+// Return the details of the session currently running, according to SessionNum.
+func (sdk *IRSDK) currentSession() *SessionDetails {
+	if sdk.Session == nil {
+		return nil
+	}
+
+	num := sdk.varInt("SessionNum")
+	for i := range sdk.Session.SessionInfo.Sessions {
+		if sdk.Session.SessionInfo.Sessions[i].SessionNum == num {
+			return &sdk.Session.SessionInfo.Sessions[i]
+		}
+	}
+	return nil
+}
+
+// Parse the session string read from the shared memory or from an IBT file.
+func parseSession(raw string) (*Session, error) {
+	s := Session{}
+	if err := yaml.Unmarshal([]byte(sanitizeSessionYaml(raw)), &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// This function updates the session data in the sdk struct.
+// On a parse error the previous session data is kept.
+func updateSessionData(sdk *IRSDK) {
+	newSession, err := parseSession(readSessionData(sdk))
+	if err != nil {
+		log.Printf("session data not updated: %v", err)
+		return
 	}
 
-	sdk.Session = &newSession
+	sdk.Session = newSession
 }
@@ -0,0 +1,174 @@
+package irsdk
+
+import (
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+type LEDColor int
+
+const (
+	LEDOff LEDColor = iota
+	LEDGreen
+	LEDYellow
+	LEDRed
+	LEDBlue
+)
+
+// ShiftLightProfile configures the shift lights of a car. RPM values left to
+// zero are taken from the session data of the player's car.
+type ShiftLightProfile struct {
+	CarPath  string          `yaml:"CarPath"`
+	LEDs     int             `yaml:"LEDs"`
+	FirstRPM float64         `yaml:"FirstRPM"`
+	ShiftRPM float64         `yaml:"ShiftRPM"`
+	LastRPM  float64         `yaml:"LastRPM"`
+	BlinkRPM float64         `yaml:"BlinkRPM"`
+	GearRPM  map[int]float64 `yaml:"GearRPM"` // shift RPM by gear, overriding ShiftRPM
+}
+
+// ShiftLightState is the state of the LEDs for a single tick.
+type ShiftLightState struct {
+	LEDs  []LEDColor
+	Lit   int
+	Shift bool
+	Blink bool
+}
+
+// ShiftLights computes the LED states from RPM and Gear. Call Update on every tick.
+type ShiftLights struct {
+	// Number of LEDs used when no profile is found for the car.
+	DefaultLEDs int
+	// Blink frequency in Hz.
+	BlinkRate float64
+	// Profiles by CarPath.
+	Profiles map[string]ShiftLightProfile
+}
+
+func NewShiftLights() *ShiftLights {
+	return &ShiftLights{
+		DefaultLEDs: 10,
+		BlinkRate:   4,
+		Profiles:    make(map[string]ShiftLightProfile),
+	}
+}
+
+// Load the car profiles from a YAML file containing a list of ShiftLightProfile.
+func (s *ShiftLights) LoadProfiles(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var profiles []ShiftLightProfile
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		return err
+	}
+
+	for _, p := range profiles {
+		s.Profiles[p.CarPath] = p
+	}
+	return nil
+}
+
+// Return the profile of the player's car, completed with the session values.
+func (s *ShiftLights) profile(sdk *IRSDK) ShiftLightProfile {
+	p := ShiftLightProfile{LEDs: s.DefaultLEDs}
+	if sdk.Session == nil {
+		return p
+	}
+
+	info := sdk.Session.DriverInfo
+	for _, d := range info.Drivers {
+		if d.CarIdx == info.DriverCarIdx {
+			if found, ok := s.Profiles[d.CarPath]; ok {
+				p = found
+			}
+		}
+	}
+
+	if p.LEDs <= 0 {
+		p.LEDs = s.DefaultLEDs
+	}
+	if p.FirstRPM == 0 {
+		p.FirstRPM = info.DriverCarSLFirstRPM
+	}
+	if p.ShiftRPM == 0 {
+		p.ShiftRPM = info.DriverCarSLShiftRPM
+	}
+	if p.LastRPM == 0 {
+		p.LastRPM = info.DriverCarSLLastRPM
+	}
+	if p.BlinkRPM == 0 {
+		p.BlinkRPM = info.DriverCarSLBlinkRPM
+	}
+	return p
+}
+
+// Return the state of the shift lights for the current telemetry.
+func (s *ShiftLights) Update(sdk *IRSDK) ShiftLightState {
+	p := s.profile(sdk)
+	rpm := float64(sdk.varFloat("RPM"))
+	gear := sdk.varInt("Gear")
+
+	state := ShiftLightState{LEDs: make([]LEDColor, p.LEDs)}
+
+	// A gear specific shift point moves the whole RPM band.
+	offset := 0.0
+	if r, ok := p.GearRPM[gear]; ok && r > 0 {
+		offset = r - p.ShiftRPM
+	}
+	first := p.FirstRPM + offset
+	shift := p.ShiftRPM + offset
+	last := p.LastRPM + offset
+	blink := p.BlinkRPM + offset
+	if last <= first {
+		last = shift
+	}
+	if last <= first {
+		return state
+	}
+
+	switch {
+	case rpm >= last:
+		state.Lit = p.LEDs
+	case rpm > first:
+		state.Lit = int(float64(p.LEDs) * (rpm - first) / (last - first))
+	}
+	state.Shift = rpm >= shift
+
+	// No shift lights in neutral and reverse.
+	if gear <= 0 {
+		state.Lit = 0
+		state.Shift = false
+	}
+
+	for i := 0; i < state.Lit; i++ {
+		switch {
+		case state.Shift:
+			state.LEDs[i] = LEDBlue
+		case i < p.LEDs/3:
+			state.LEDs[i] = LEDGreen
+		case i < p.LEDs*2/3:
+			state.LEDs[i] = LEDYellow
+		default:
+			state.LEDs[i] = LEDRed
+		}
+	}
+
+	// Over the blink RPM all the LEDs flash together.
+	if p.BlinkRPM > 0 && rpm >= blink && gear > 0 {
+		state.Blink = true
+		phase := int(sdk.varDouble("SessionTime")*s.BlinkRate*2) % 2
+		for i := range state.LEDs {
+			if phase == 0 {
+				state.LEDs[i] = LEDRed
+			} else {
+				state.LEDs[i] = LEDOff
+			}
+		}
+	}
+
+	return state
+}
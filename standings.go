@@ -0,0 +1,227 @@
+package irsdk
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Standing is a single row of the timing tower.
+type Standing struct {
+	CarIdx     int
+	CarNumber  string
+	DriverName string
+	TeamName   string
+	ClassID    int
+	ClassName  string
+
+	Position      int
+	ClassPosition int
+	Lap           int
+	LapDistPct    float32
+
+	// Gaps are in seconds and relative to the class leader and to the car
+	// ahead in the same class. Outside of races they are the differences
+	// between the best lap times and LapsDown is always 0.
+	GapToLeader float64
+	Interval    float64
+	LapsDown    int
+
+	OnPitRoad  bool
+	InPitStall bool
+
+	BestLapTime float64
+	LastLapTime float64
+
+	// Grid position from the qualifying results, 0 when unknown or
+	// outside of races.
+	StartPosition   int
+	PositionsGained int
+}
+
+// ClassStandings is the leaderboard of a single car class.
+type ClassStandings struct {
+	ClassID   int
+	ClassName string
+	Cars      []Standing
+}
+
+// Standings is an ordered leaderboard, updated on every tick.
+type Standings struct {
+	Overall []Standing
+	Classes []ClassStandings
+}
+
+func NewStandings() *Standings {
+	return &Standings{}
+}
+
+// Rebuild the leaderboard from the current telemetry and session data.
+func (s *Standings) Update(sdk *IRSDK) {
+	race := false
+	results := make(map[int]ResultsPosition)
+	if details := sdk.currentSession(); details != nil {
+		race = strings.Contains(details.SessionType, "Race")
+		for _, r := range details.ResultsPositions {
+			results[r.CarIdx] = r
+		}
+	}
+
+	var grid map[int]int
+	if race {
+		grid = gridPositions(sdk.Session)
+	}
+
+	rows := make([]Standing, 0)
+	progress := make(map[int]float64)
+	f2Time := make(map[int]float64)
+
+	for _, car := range sdk.Cars() {
+		if car.Driver.CarIsPaceCar != 0 {
+			continue
+		}
+
+		res, hasResult := results[car.CarIdx]
+
+		row := Standing{
+			CarIdx:        car.CarIdx,
+			CarNumber:     car.Driver.CarNumber,
+			DriverName:    car.Driver.UserName,
+			TeamName:      car.Driver.TeamName,
+			ClassID:       car.Driver.CarClassID,
+			ClassName:     car.Driver.CarClassShortName,
+			Position:      car.Position,
+			ClassPosition: car.ClassPosition,
+			Lap:           car.Lap,
+			LapDistPct:    car.LapDistPct,
+			OnPitRoad:     car.OnPitRoad,
+			InPitStall:    car.TrackSurface == LocationInPitStall,
+			BestLapTime:   float64(car.BestLapTime),
+			LastLapTime:   float64(car.LastLapTime),
+		}
+
+		if hasResult {
+			if row.Position <= 0 {
+				row.Position = res.Position
+				// Class positions in the results are zero based.
+				row.ClassPosition = res.ClassPosition + 1
+			}
+			if row.BestLapTime <= 0 {
+				row.BestLapTime = res.FastestTime
+			}
+			if row.LastLapTime <= 0 {
+				row.LastLapTime = res.LastTime
+			}
+		}
+
+		row.StartPosition = grid[car.CarIdx]
+		if row.StartPosition > 0 && row.Position > 0 {
+			row.PositionsGained = row.StartPosition - row.Position
+		}
+
+		p := float64(car.LapDistPct)
+		if p < 0 {
+			p = 0
+		}
+		progress[car.CarIdx] = float64(car.Lap) + p
+		f2Time[car.CarIdx] = float64(car.F2Time)
+
+		rows = append(rows, row)
+	}
+
+	// Scored cars first by position, then the others by distance covered.
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if (a.Position > 0) != (b.Position > 0) {
+			return a.Position > 0
+		}
+		if a.Position > 0 {
+			return a.Position < b.Position
+		}
+		return progress[a.CarIdx] > progress[b.CarIdx]
+	})
+
+	rowIndex := make(map[int]int)
+	classIndex := make(map[int]int)
+	classes := make([]ClassStandings, 0)
+	for k, row := range rows {
+		rowIndex[row.CarIdx] = k
+
+		i, ok := classIndex[row.ClassID]
+		if !ok {
+			i = len(classes)
+			classIndex[row.ClassID] = i
+			classes = append(classes, ClassStandings{ClassID: row.ClassID, ClassName: row.ClassName})
+		}
+		classes[i].Cars = append(classes[i].Cars, row)
+	}
+
+	for i := range classes {
+		cars := classes[i].Cars
+		leader := cars[0]
+
+		for j := range cars {
+			car := &cars[j]
+			if car.ClassPosition <= 0 {
+				car.ClassPosition = j + 1
+			}
+			if j == 0 {
+				continue
+			}
+			if !race {
+				// CarIdxF2Time means nothing outside of races.
+				car.GapToLeader = bestLapGap(leader.BestLapTime, car.BestLapTime)
+				car.Interval = bestLapGap(cars[j-1].BestLapTime, car.BestLapTime)
+				continue
+			}
+			car.LapsDown = max(0, int(math.Floor(progress[leader.CarIdx]-progress[car.CarIdx])))
+			car.GapToLeader = f2Time[car.CarIdx] - f2Time[leader.CarIdx]
+			car.Interval = f2Time[car.CarIdx] - f2Time[cars[j-1].CarIdx]
+		}
+
+		// Keep the overall rows in sync with the class computations.
+		for _, car := range cars {
+			rows[rowIndex[car.CarIdx]] = car
+		}
+	}
+
+	s.Overall = rows
+	s.Classes = classes
+}
+
+// Return the difference between two best lap times, 0 if either is unknown.
+func bestLapGap(ahead float64, behind float64) float64 {
+	if ahead <= 0 || behind <= 0 {
+		return 0
+	}
+	return behind - ahead
+}
+
+// Return the grid positions by CarIdx, starting from 1. They come from the
+// qualifying results of the weekend, or from the results of the last
+// qualifying session. Returns nil when neither is available.
+func gridPositions(s *Session) map[int]int {
+	if s == nil {
+		return nil
+	}
+
+	if results := s.QualifyResultsInfo.Results; len(results) > 0 {
+		grid := make(map[int]int)
+		for _, r := range results {
+			grid[r.CarIdx] = r.Position + 1
+		}
+		return grid
+	}
+
+	var grid map[int]int
+	for _, details := range s.SessionInfo.Sessions {
+		if !strings.Contains(details.SessionType, "Qualify") || len(details.ResultsPositions) == 0 {
+			continue
+		}
+		grid = make(map[int]int)
+		for _, r := range details.ResultsPositions {
+			grid[r.CarIdx] = r.Position
+		}
+	}
+	return grid
+}
@@ -0,0 +1,67 @@
+package irsdk
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGridPositions(t *testing.T) {
+	qualifying := SessionDetails{
+		SessionType: "Lone Qualify",
+		ResultsPositions: []ResultsPosition{
+			{Position: 1, CarIdx: 4},
+			{Position: 2, CarIdx: 2},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		session *Session
+		want    map[int]int
+	}{
+		{"no session", nil, nil},
+		{"no qualifying", &Session{SessionInfo: SessionInfo{Sessions: []SessionDetails{
+			{SessionType: "Practice", ResultsPositions: []ResultsPosition{{Position: 1, CarIdx: 3}}},
+			{SessionType: "Race"},
+		}}}, nil},
+		{"qualifying session", &Session{SessionInfo: SessionInfo{Sessions: []SessionDetails{
+			{SessionType: "Practice", ResultsPositions: []ResultsPosition{{Position: 1, CarIdx: 3}}},
+			qualifying,
+			{SessionType: "Race"},
+		}}}, map[int]int{4: 1, 2: 2}},
+		{"qualify results info", &Session{
+			SessionInfo: SessionInfo{Sessions: []SessionDetails{qualifying}},
+			QualifyResultsInfo: QualifyResultsInfo{Results: []QualifyResult{
+				{Position: 0, CarIdx: 2},
+				{Position: 1, CarIdx: 4},
+			}},
+		}, map[int]int{2: 1, 4: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gridPositions(tt.session); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBestLapGap(t *testing.T) {
+	tests := []struct {
+		ahead  float64
+		behind float64
+		want   float64
+	}{
+		{90.5, 91, 0.5},
+		{90.5, 90.5, 0},
+		{0, 91, 0},
+		{90.5, -1, 0},
+	}
+
+	for _, tt := range tests {
+		if got := bestLapGap(tt.ahead, tt.behind); got != tt.want {
+			t.Errorf("bestLapGap(%v, %v) = %v, want %v", tt.ahead, tt.behind, got, tt.want)
+		}
+	}
+}
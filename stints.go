@@ -0,0 +1,156 @@
+package irsdk
+
+// Stint is a continuous period in the car of a single driver. Times are
+// session times in seconds; EndTime is zero while the stint is in progress.
+// FuelUsed is only known for the player's car.
+type Stint struct {
+	CarIdx   int
+	TeamID   int
+	UserID   int
+	UserName string
+
+	StartTime float64
+	EndTime   float64
+	StartLap  int
+	EndLap    int
+	Laps      int
+	FuelUsed  float64
+	Incidents int
+
+	startIncidents int
+}
+
+// DriveTime is the seat time of a driver of a team.
+type DriveTime struct {
+	UserID   int
+	UserName string
+	Stints   int
+	Laps     int
+	SeatTime float64
+
+	// Seat time still needed to reach StintTracker.MinDriveTime.
+	Remaining float64
+	Met       bool
+}
+
+// StintTracker detects driver swaps in team events from the UserID of every
+// car in the session data. Call Update on every tick.
+type StintTracker struct {
+	// Minimum seat time in seconds required for every driver of a team.
+	MinDriveTime float64
+
+	// Stints by CarIdx, the last one is the current stint.
+	Cars map[int][]*Stint
+
+	sessionNum int
+	session    *Session
+	now        float64
+	lastFuel   float64
+}
+
+func NewStintTracker(minDriveTime float64) *StintTracker {
+	return &StintTracker{
+		MinDriveTime: minDriveTime,
+		Cars:         make(map[int][]*Stint),
+		sessionNum:   -1,
+	}
+}
+
+// Consume the current data and return the stints started since the previous call.
+func (t *StintTracker) Update(sdk *IRSDK) []*Stint {
+	sessionNum := sdk.varInt("SessionNum")
+	if sessionNum != t.sessionNum {
+		t.sessionNum = sessionNum
+		t.Cars = make(map[int][]*Stint)
+		t.session = nil
+	}
+
+	t.now = sdk.varDouble("SessionTime")
+	lapCompleted := sdk.varInts("CarIdxLapCompleted")
+
+	// Fuel is only available for the player's car.
+	fuel := float64(sdk.varFloat("FuelLevel"))
+	if sdk.Session != nil {
+		if stints := t.Cars[sdk.Session.DriverInfo.DriverCarIdx]; len(stints) > 0 {
+			if used := t.lastFuel - fuel; used > 0 {
+				stints[len(stints)-1].FuelUsed += used
+			}
+		}
+	}
+	t.lastFuel = fuel
+
+	for _, stints := range t.Cars {
+		cur := stints[len(stints)-1]
+		cur.EndLap = max(cur.EndLap, at(lapCompleted, cur.CarIdx))
+		cur.Laps = cur.EndLap - cur.StartLap
+	}
+
+	if sdk.Session == nil || sdk.Session == t.session {
+		return nil
+	}
+	t.session = sdk.Session
+
+	var started []*Stint
+
+	for _, d := range sdk.Session.DriverInfo.Drivers {
+		if d.IsSpectator != 0 || d.CarIsPaceCar != 0 {
+			continue
+		}
+
+		stints := t.Cars[d.CarIdx]
+		if len(stints) > 0 {
+			cur := stints[len(stints)-1]
+			if cur.UserID == d.UserID {
+				cur.Incidents = d.CurDriverIncidentCount - cur.startIncidents
+				continue
+			}
+			cur.EndTime = t.now
+		}
+
+		lap := at(lapCompleted, d.CarIdx)
+		s := &Stint{
+			CarIdx:         d.CarIdx,
+			TeamID:         d.TeamID,
+			UserID:         d.UserID,
+			UserName:       d.UserName,
+			StartTime:      t.now,
+			StartLap:       lap,
+			EndLap:         lap,
+			startIncidents: d.CurDriverIncidentCount,
+		}
+		t.Cars[d.CarIdx] = append(stints, s)
+		started = append(started, s)
+	}
+
+	return started
+}
+
+// Return the seat time of every driver of a car, in order of first stint.
+func (t *StintTracker) DriveTimes(carIdx int) []DriveTime {
+	var times []DriveTime
+	index := make(map[int]int)
+
+	for _, s := range t.Cars[carIdx] {
+		i, ok := index[s.UserID]
+		if !ok {
+			i = len(times)
+			index[s.UserID] = i
+			times = append(times, DriveTime{UserID: s.UserID, UserName: s.UserName})
+		}
+
+		end := s.EndTime
+		if end == 0 {
+			end = t.now
+		}
+		times[i].Stints++
+		times[i].Laps += s.Laps
+		times[i].SeatTime += end - s.StartTime
+	}
+
+	for i := range times {
+		times[i].Remaining = max(0, t.MinDriveTime-times[i].SeatTime)
+		times[i].Met = times[i].Remaining == 0
+	}
+
+	return times
+}
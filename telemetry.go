@@ -168,17 +168,8 @@ func findLatestBuffer(h *header) *varBuf {
 	return &h.VarBuf[lastTickIndex]
 }
 
-// This function updates LastTickCount and Telemetry fields of the IRSDK struct.
-func updateTelemetryVariables(sdk *IRSDK) bool {
-	vb := findLatestBuffer(sdk.Header)
-
-	// If the tick count is the same as the last one read, return false.
-	// If it's lower than the last one read, it means the data has been reset, maybe because the sim has been restarted.
-	if vb.TickCount == sdk.LastTickCount {
-		return false
-	}
-
-	headers := readVariableHeaders(sdk.Reader, sdk.Header)
+// Read the value of every variable from the buffer starting at bufOffset.
+func readTelemetryBuffer(r reader, headers map[string]varHeader, bufOffset int) map[string]TelemetryVar {
 	vars := make(map[string]TelemetryVar, len(headers))
 
 	for varName, v := range headers {
@@ -186,7 +177,7 @@ func updateTelemetryVariables(sdk *IRSDK) bool {
 
 		rbuf := make([]byte, bufferSize)
 
-		_, err := sdk.Reader.ReadAt(rbuf, int64(vb.BufOffset+v.Offset))
+		_, err := r.ReadAt(rbuf, int64(bufOffset+v.Offset))
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -197,9 +188,88 @@ func updateTelemetryVariables(sdk *IRSDK) bool {
 		}
 	}
 
+	return vars
+}
+
+// This function updates LastTickCount and Telemetry fields of the IRSDK struct.
+func updateTelemetryVariables(sdk *IRSDK) bool {
+	vb := findLatestBuffer(sdk.Header)
+
+	// If the tick count is the same as the last one read, return false.
+	// If it's lower than the last one read, it means the data has been reset, maybe because the sim has been restarted.
+	if vb.TickCount == sdk.LastTickCount {
+		return false
+	}
+
+	headers := readVariableHeaders(sdk.Reader, sdk.Header)
+
 	sdk.LastTickCount = vb.TickCount
-	sdk.Telemetry = vars
+	sdk.Telemetry = readTelemetryBuffer(sdk.Reader, headers, vb.BufOffset)
 	sdk.LastDataTime = time.Now().Unix()
 
 	return true
 }
+
+// Typed accessors used by the helpers built on top of the telemetry map.
+// They return the zero value when the variable is missing or has another type.
+
+func (sdk *IRSDK) varInt(name string) int {
+	v, _ := sdk.Telemetry[name].single().(int)
+	return v
+}
+
+func (sdk *IRSDK) varFloat(name string) float32 {
+	v, _ := sdk.Telemetry[name].single().(float32)
+	return v
+}
+
+func (sdk *IRSDK) varDouble(name string) float64 {
+	v, _ := sdk.Telemetry[name].single().(float64)
+	return v
+}
+
+func (sdk *IRSDK) varBool(name string) bool {
+	v, _ := sdk.Telemetry[name].single().(bool)
+	return v
+}
+
+func (sdk *IRSDK) varBitField(name string) uint32 {
+	v, _ := sdk.Telemetry[name].single().(uint32)
+	return v
+}
+
+func (sdk *IRSDK) varInts(name string) []int {
+	v, _ := sdk.Telemetry[name].array().([]int)
+	return v
+}
+
+func (sdk *IRSDK) varFloats(name string) []float32 {
+	v, _ := sdk.Telemetry[name].array().([]float32)
+	return v
+}
+
+func (sdk *IRSDK) varBools(name string) []bool {
+	v, _ := sdk.Telemetry[name].array().([]bool)
+	return v
+}
+
+func (sdk *IRSDK) varBitFields(name string) []uint32 {
+	v, _ := sdk.Telemetry[name].array().([]uint32)
+	return v
+}
+
+// Like Single and Array, but safe to call on the zero TelemetryVar returned
+// for a missing variable.
+func (v TelemetryVar) single() interface{} {
+	if len(v.RawValue) < VarTypeBytes[v.Header.Type] {
+		return nil
+	}
+	return v.Single()
+}
+
+func (v TelemetryVar) array() interface{} {
+	if len(v.RawValue) < VarTypeBytes[v.Header.Type]*v.Header.Count {
+		return nil
+	}
+	return v.Array()
+}
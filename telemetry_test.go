@@ -0,0 +1,100 @@
+package irsdk
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// Helpers building the telemetry variables of a fake sim. A single value is
+// a scalar, more values an array.
+
+func intVar(vals ...int) TelemetryVar {
+	raw := make([]byte, 4*len(vals))
+	for i, v := range vals {
+		binary.LittleEndian.PutUint32(raw[4*i:], uint32(v))
+	}
+	return TelemetryVar{varHeader{Type: VarTypeInt, Count: len(vals)}, raw}
+}
+
+func bitFieldVar(vals ...uint32) TelemetryVar {
+	raw := make([]byte, 4*len(vals))
+	for i, v := range vals {
+		binary.LittleEndian.PutUint32(raw[4*i:], v)
+	}
+	return TelemetryVar{varHeader{Type: VarTypeBitField, Count: len(vals)}, raw}
+}
+
+func floatVar(vals ...float32) TelemetryVar {
+	raw := make([]byte, 4*len(vals))
+	for i, v := range vals {
+		binary.LittleEndian.PutUint32(raw[4*i:], math.Float32bits(v))
+	}
+	return TelemetryVar{varHeader{Type: VarTypeFloat, Count: len(vals)}, raw}
+}
+
+func doubleVar(v float64) TelemetryVar {
+	raw := make([]byte, 8)
+	binary.LittleEndian.PutUint64(raw, math.Float64bits(v))
+	return TelemetryVar{varHeader{Type: VarTypeDouble, Count: 1}, raw}
+}
+
+func boolVar(vals ...bool) TelemetryVar {
+	raw := make([]byte, len(vals))
+	for i, v := range vals {
+		if v {
+			raw[i] = 1
+		}
+	}
+	return TelemetryVar{varHeader{Type: VarTypeBool, Count: len(vals)}, raw}
+}
+
+func TestTypedAccessors(t *testing.T) {
+	sdk := &IRSDK{Telemetry: map[string]TelemetryVar{
+		"Gear":               intVar(-1),
+		"Speed":              floatVar(42.5),
+		"SessionTime":        doubleVar(123.25),
+		"OnPitRoad":          boolVar(true),
+		"SessionFlags":       bitFieldVar(0x80000000),
+		"CarIdxLap":          intVar(3, -1, 5),
+		"CarIdxLapDistPct":   floatVar(0.5, -1),
+		"CarIdxOnPitRoad":    boolVar(false, true),
+		"CarIdxSessionFlags": bitFieldVar(1, 2),
+	}}
+
+	if got := sdk.varInt("Gear"); got != -1 {
+		t.Errorf("varInt = %d", got)
+	}
+	if got := sdk.varFloat("Speed"); got != 42.5 {
+		t.Errorf("varFloat = %v", got)
+	}
+	if got := sdk.varDouble("SessionTime"); got != 123.25 {
+		t.Errorf("varDouble = %v", got)
+	}
+	if !sdk.varBool("OnPitRoad") {
+		t.Error("varBool = false")
+	}
+	if got := sdk.varBitField("SessionFlags"); got != 0x80000000 {
+		t.Errorf("varBitField = %#x", got)
+	}
+	if got := sdk.varInts("CarIdxLap"); len(got) != 3 || got[1] != -1 || got[2] != 5 {
+		t.Errorf("varInts = %v", got)
+	}
+	if got := sdk.varFloats("CarIdxLapDistPct"); len(got) != 2 || got[1] != -1 {
+		t.Errorf("varFloats = %v", got)
+	}
+	if got := sdk.varBools("CarIdxOnPitRoad"); len(got) != 2 || !got[1] {
+		t.Errorf("varBools = %v", got)
+	}
+	if got := sdk.varBitFields("CarIdxSessionFlags"); len(got) != 2 || got[1] != 2 {
+		t.Errorf("varBitFields = %v", got)
+	}
+
+	// Missing variables and variables of another type return the zero value.
+	if sdk.varInt("Missing") != 0 || sdk.varInts("Missing") != nil {
+		t.Error("missing variable not zero")
+	}
+	if sdk.varInt("Speed") != 0 || sdk.varFloats("CarIdxLap") != nil {
+		t.Error("variable of another type not zero")
+	}
+}
@@ -0,0 +1,240 @@
+package irsdk
+
+import (
+	"slices"
+)
+
+// Maximum LapDistPct change between two ticks still treated as driving.
+// Bigger jumps come from tows, resets or replays and break the current lap.
+const maxLapDistPctJump = 0.1
+
+// LapEvent is emitted by Timing every time a car crosses the start/finish line
+// after completing a full lap.
+type LapEvent struct {
+	CarIdx      int
+	Lap         int
+	SessionTime float64
+	LapTime     float64
+	Sectors     []float64
+
+	PersonalBest bool
+	ClassBest    bool
+	OverallBest  bool
+}
+
+// TimingBest is the fastest time set for a lap or a sector.
+type TimingBest struct {
+	CarIdx int
+	Lap    int
+	Time   float64
+}
+
+// CarTiming holds the best times of a single car.
+type CarTiming struct {
+	BestLap     TimingBest
+	BestSectors []TimingBest
+	LastLap     float64
+	LastSectors []float64
+}
+
+// Timing computes lap and sector times for every car from CarIdxLapDistPct,
+// CarIdxLap and SessionTime. Call Update on every tick.
+type Timing struct {
+	Cars        map[int]*CarTiming
+	ClassBest   map[int]TimingBest
+	OverallBest TimingBest
+
+	ClassBestSectors   map[int][]TimingBest
+	OverallBestSectors []TimingBest
+
+	sessionNum int
+	lastTime   float64
+	states     map[int]*lapState
+}
+
+type lapState struct {
+	pct         float64
+	lap         int
+	started     bool
+	lapStart    float64
+	sectorStart float64
+	sectors     []float64
+}
+
+func NewTiming() *Timing {
+	t := &Timing{sessionNum: -1}
+	t.reset()
+	return t
+}
+
+func (t *Timing) reset() {
+	t.Cars = make(map[int]*CarTiming)
+	t.ClassBest = make(map[int]TimingBest)
+	t.OverallBest = TimingBest{}
+	t.ClassBestSectors = make(map[int][]TimingBest)
+	t.OverallBestSectors = nil
+	t.states = make(map[int]*lapState)
+}
+
+// Consume the current telemetry and return the laps completed since the previous call.
+func (t *Timing) Update(sdk *IRSDK) []LapEvent {
+	sessionNum := sdk.varInt("SessionNum")
+	if sessionNum != t.sessionNum {
+		t.reset()
+		t.sessionNum = sessionNum
+	}
+
+	now := sdk.varDouble("SessionTime")
+	if now <= t.lastTime {
+		// Same tick or time going backwards (replay): nothing to interpolate.
+		t.lastTime = now
+		return nil
+	}
+	prevTime := t.lastTime
+	t.lastTime = now
+
+	sectors := sectorStarts(sdk.Session)
+	drivers := sdk.driversByCarIdx()
+	pcts := sdk.varFloats("CarIdxLapDistPct")
+	laps := sdk.varInts("CarIdxLap")
+
+	var events []LapEvent
+
+	for idx, p := range pcts {
+		pct := float64(p)
+		st, ok := t.states[idx]
+
+		if pct < 0 {
+			delete(t.states, idx)
+			continue
+		}
+		if !ok {
+			t.states[idx] = &lapState{pct: pct, lap: at(laps, idx)}
+			continue
+		}
+
+		end := pct
+		wrapped := st.pct-pct > 0.5
+		if wrapped {
+			end += 1
+		}
+		if end < st.pct || end-st.pct > maxLapDistPctJump {
+			// Going backwards or teleported: restart from a clean state.
+			t.states[idx] = &lapState{pct: pct, lap: at(laps, idx)}
+			continue
+		}
+
+		// Interpolate the session time at which a given position was crossed.
+		crossTime := func(pos float64) float64 {
+			return prevTime + (now-prevTime)*(pos-st.pct)/(end-st.pct)
+		}
+
+		// Sector boundaries crossed during this tick, in track order. The
+		// start/finish line is the start of sector 0 on the next lap.
+		var crossed []float64
+		for _, b := range sectors {
+			for _, pos := range []float64{b, b + 1} {
+				if st.pct < pos && pos <= end {
+					crossed = append(crossed, pos)
+				}
+			}
+		}
+		slices.Sort(crossed)
+
+		for _, pos := range crossed {
+			ct := crossTime(pos)
+
+			if pos != 1 {
+				if st.started {
+					st.sectors = append(st.sectors, ct-st.sectorStart)
+				}
+				st.sectorStart = ct
+				continue
+			}
+
+			if st.started {
+				st.sectors = append(st.sectors, ct-st.sectorStart)
+				class := drivers[idx].CarClassID
+				events = append(events, t.completeLap(idx, class, st.lap, now, ct-st.lapStart, st.sectors, len(sectors)))
+			}
+			st.started = true
+			st.lapStart = ct
+			st.sectorStart = ct
+			st.sectors = nil
+		}
+
+		st.pct = pct
+		st.lap = at(laps, idx)
+	}
+
+	return events
+}
+
+// Record a completed lap, update the best times and build the event.
+func (t *Timing) completeLap(idx int, class int, lap int, now float64, lapTime float64, sectors []float64, numSectors int) LapEvent {
+	car, ok := t.Cars[idx]
+	if !ok {
+		car = &CarTiming{}
+		t.Cars[idx] = car
+	}
+
+	ev := LapEvent{
+		CarIdx:      idx,
+		Lap:         lap,
+		SessionTime: now,
+		LapTime:     lapTime,
+		Sectors:     sectors,
+	}
+
+	best := TimingBest{CarIdx: idx, Lap: lap, Time: lapTime}
+	if car.BestLap.Time == 0 || lapTime < car.BestLap.Time {
+		car.BestLap = best
+		ev.PersonalBest = true
+	}
+	if cb, ok := t.ClassBest[class]; !ok || lapTime < cb.Time {
+		t.ClassBest[class] = best
+		ev.ClassBest = true
+	}
+	if t.OverallBest.Time == 0 || lapTime < t.OverallBest.Time {
+		t.OverallBest = best
+		ev.OverallBest = true
+	}
+
+	car.LastLap = lapTime
+	car.LastSectors = sectors
+
+	// Sector bests only make sense when every sector of the lap was timed.
+	if len(sectors) == numSectors {
+		car.BestSectors = updateSectorBests(car.BestSectors, idx, lap, sectors)
+		t.ClassBestSectors[class] = updateSectorBests(t.ClassBestSectors[class], idx, lap, sectors)
+		t.OverallBestSectors = updateSectorBests(t.OverallBestSectors, idx, lap, sectors)
+	}
+
+	return ev
+}
+
+func updateSectorBests(bests []TimingBest, idx int, lap int, sectors []float64) []TimingBest {
+	if len(bests) != len(sectors) {
+		bests = make([]TimingBest, len(sectors))
+	}
+	for i, s := range sectors {
+		if bests[i].Time == 0 || s < bests[i].Time {
+			bests[i] = TimingBest{CarIdx: idx, Lap: lap, Time: s}
+		}
+	}
+	return bests
+}
+
+// Return the start position of every sector, always beginning with 0.
+func sectorStarts(s *Session) []float64 {
+	starts := []float64{0}
+	if s == nil {
+		return starts
+	}
+	for _, sec := range s.SplitTimeInfo.Sectors {
+		if sec.SectorStartPct > 0 && sec.SectorStartPct < 1 {
+			starts = append(starts, sec.SectorStartPct)
+		}
+	}
+	return starts
+}
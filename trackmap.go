@@ -0,0 +1,276 @@
+package irsdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+const earthRadius = 6378137.0
+
+// TrackPoint is a point of a track outline, normalised between 0 and 1.
+type TrackPoint struct {
+	X float64
+	Y float64
+}
+
+// TrackMap is the outline of a track, sampled at evenly spaced LapDistPct.
+type TrackMap struct {
+	TrackID         int
+	TrackConfigName string
+	Points          []TrackPoint
+}
+
+// Return the position on the outline of a car at the given LapDistPct.
+func (m *TrackMap) Position(pct float64) TrackPoint {
+	n := len(m.Points)
+	if n == 0 {
+		return TrackPoint{}
+	}
+
+	pct -= math.Floor(pct)
+	f := pct * float64(n)
+	i := int(f) % n
+	j := (i + 1) % n
+	frac := f - math.Floor(f)
+
+	return TrackPoint{
+		X: m.Points[i].X + (m.Points[j].X-m.Points[i].X)*frac,
+		Y: m.Points[i].Y + (m.Points[j].Y-m.Points[i].Y)*frac,
+	}
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// Return the path of a file with data about a track configuration.
+func trackFile(dir string, trackID int, config string, kind string) string {
+	name := fmt.Sprintf("%d", trackID)
+	if config != "" {
+		name += "-" + unsafeFileChars.ReplaceAllString(config, "_")
+	}
+	if kind != "" {
+		name += "-" + kind
+	}
+	return filepath.Join(dir, name+".json")
+}
+
+// Write the map to dir, in a file named after the track and configuration.
+func (m *TrackMap) Save(dir string) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(trackFile(dir, m.TrackID, m.TrackConfigName, ""), data, 0644)
+}
+
+// Read the map of a track saved in dir by Save.
+func LoadTrackMap(dir string, trackID int, config string) (*TrackMap, error) {
+	data, err := os.ReadFile(trackFile(dir, trackID, config, ""))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &TrackMap{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type trackSample struct {
+	pct float64
+	x   float64
+	y   float64
+}
+
+// TrackMapBuilder reconstructs the track outline from the player's car. It
+// uses Lat and Lon when available, otherwise it integrates VelocityX,
+// VelocityY and Yaw. Call Update on every tick.
+type TrackMapBuilder struct {
+	// Number of points of the outline.
+	Resolution int
+	// Number of points averaged together when smoothing the outline.
+	Smoothing int
+
+	lastTime  float64
+	lastPct   float64
+	x         float64
+	y         float64
+	clean     bool
+	recording bool
+	samples   []trackSample
+}
+
+func NewTrackMapBuilder() *TrackMapBuilder {
+	return &TrackMapBuilder{
+		Resolution: 500,
+		Smoothing:  5,
+		lastPct:    -1,
+	}
+}
+
+// Consume the current telemetry. When a clean lap is completed the new track
+// map is returned.
+func (b *TrackMapBuilder) Update(sdk *IRSDK) (*TrackMap, bool) {
+	now := sdk.varDouble("SessionTime")
+	pct := float64(sdk.varFloat("LapDistPct"))
+	dt := now - b.lastTime
+	b.lastTime = now
+
+	if dt <= 0 || dt > 1 || pct < 0 || !sdk.varBool("IsOnTrack") {
+		b.recording = false
+		b.lastPct = -1
+		return nil, false
+	}
+
+	surface := TrackSurface(sdk.varInt("PlayerTrackSurfaceMaterial"))
+	if sdk.varBool("OnPitRoad") || isOffTrackSurface(surface) {
+		b.clean = false
+	}
+
+	var m *TrackMap
+	crossed := b.lastPct-pct > 0.5
+	if b.lastPct >= 0 && !crossed && (pct < b.lastPct || pct-b.lastPct > maxLapDistPctJump) {
+		// Reset or tow: drop the lap in progress.
+		b.recording = false
+	}
+
+	if crossed {
+		if b.recording && b.clean {
+			m = b.build(sdk)
+		}
+		b.recording = true
+		b.clean = !sdk.varBool("OnPitRoad")
+		b.samples = b.samples[:0]
+		b.x, b.y = 0, 0
+	}
+
+	if b.recording {
+		_, hasLat := sdk.Telemetry["Lat"]
+		_, hasLon := sdk.Telemetry["Lon"]
+		if hasLat && hasLon {
+			b.x, b.y = projectLatLon(sdk.varDouble("Lat"), sdk.varDouble("Lon"))
+		} else {
+			vx := float64(sdk.varFloat("VelocityX"))
+			vy := float64(sdk.varFloat("VelocityY"))
+			yaw := float64(sdk.varFloat("Yaw"))
+			// The velocity is in the car frame: X forward, Y left.
+			b.x += (vx*math.Cos(yaw) - vy*math.Sin(yaw)) * dt
+			b.y += (vx*math.Sin(yaw) + vy*math.Cos(yaw)) * dt
+		}
+		b.samples = append(b.samples, trackSample{pct, b.x, b.y})
+	}
+
+	b.lastPct = pct
+	return m, m != nil
+}
+
+// Project a position in degrees to meters around the equator.
+func projectLatLon(lat float64, lon float64) (float64, float64) {
+	x := earthRadius * lon * math.Pi / 180
+	y := earthRadius * math.Log(math.Tan(math.Pi/4+lat*math.Pi/360))
+	return x, y
+}
+
+// Grass, dirt, sand and gravel are all after the racing surfaces.
+func isOffTrackSurface(s TrackSurface) bool {
+	return s >= SurfaceGrass1Material
+}
+
+// Turn the samples of the last lap into a closed, normalised and smoothed outline.
+func (b *TrackMapBuilder) build(sdk *IRSDK) *TrackMap {
+	n := b.Resolution
+	if len(b.samples) < 2 || n < 2 {
+		return nil
+	}
+
+	samples := b.samples
+	sort.Slice(samples, func(i, j int) bool { return samples[i].pct < samples[j].pct })
+
+	// Resample at evenly spaced positions.
+	points := make([]TrackPoint, n)
+	k := 0
+	for i := range points {
+		pct := float64(i) / float64(n)
+		for k < len(samples)-2 && samples[k+1].pct < pct {
+			k++
+		}
+		a, c := samples[k], samples[k+1]
+		frac := 0.0
+		if c.pct > a.pct {
+			frac = math.Max(0, math.Min(1, (pct-a.pct)/(c.pct-a.pct)))
+		}
+		points[i] = TrackPoint{a.x + (c.x-a.x)*frac, a.y + (c.y-a.y)*frac}
+	}
+
+	// Spread the integration drift along the lap so that the outline is closed.
+	first := samples[0]
+	prev, last := samples[len(samples)-2], samples[len(samples)-1]
+	ex, ey := last.x, last.y
+	if last.pct > prev.pct {
+		// Where the car would have been at the line, extrapolated from the last samples.
+		f := (1 + first.pct - last.pct) / (last.pct - prev.pct)
+		ex += (last.x - prev.x) * f
+		ey += (last.y - prev.y) * f
+	}
+	for i := range points {
+		f := float64(i) / float64(n)
+		points[i].X -= (ex - first.x) * f
+		points[i].Y -= (ey - first.y) * f
+	}
+
+	points = smoothTrack(points, b.Smoothing)
+	normaliseTrack(points)
+
+	m := &TrackMap{Points: points}
+	if sdk.Session != nil {
+		m.TrackID = sdk.Session.WeekendInfo.TrackID
+		m.TrackConfigName = sdk.Session.WeekendInfo.TrackConfigName
+	}
+	return m
+}
+
+// Moving average over a closed outline.
+func smoothTrack(points []TrackPoint, window int) []TrackPoint {
+	n := len(points)
+	if window <= 1 {
+		return points
+	}
+
+	out := make([]TrackPoint, n)
+	for i := range points {
+		var sx, sy float64
+		for j := -window / 2; j <= window/2; j++ {
+			p := points[((i+j)%n+n)%n]
+			sx += p.X
+			sy += p.Y
+		}
+		count := float64(window/2*2 + 1)
+		out[i] = TrackPoint{sx / count, sy / count}
+	}
+	return out
+}
+
+// Scale the outline between 0 and 1, keeping the aspect ratio, with the Y
+// axis pointing down as on screen.
+func normaliseTrack(points []TrackPoint) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range points {
+		minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+		minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+	}
+
+	size := math.Max(maxX-minX, maxY-minY)
+	if size == 0 {
+		return
+	}
+	for i := range points {
+		points[i].X = (points[i].X - minX) / size
+		points[i].Y = (maxY - points[i].Y) / size
+	}
+}
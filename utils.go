@@ -9,7 +9,11 @@ import (
 )
 
 func Byte4ToInt(in []byte) int {
-	return int(binary.LittleEndian.Uint32(in))
+	return int(int32(binary.LittleEndian.Uint32(in)))
+}
+
+func Byte8ToInt(in []byte) int {
+	return int(binary.LittleEndian.Uint64(in))
 }
 
 func Byte4ToFloat(in []byte) float32 {